@@ -65,6 +65,14 @@ func main() {
 		&models.LegalService{}, &models.ServiceMilestone{}, &models.CaseMilestone{},
 		&models.ServiceDocument{}, &models.ServiceExpense{},
 		&models.Notification{},
+		&models.ContractReminder{},
+		&models.CaseApproval{},
+		&models.EmailLog{},
+		&models.CaseExternalAccess{},
+		&models.RegistryLookup{},
+		&models.LegalHold{},
+		&models.DocumentAnnotation{},
+		&models.FirmRole{},
 		// Compliance models (Law 1581 - Habeas Data)
 		&models.ConsentLog{}, &models.SubjectRightsRequest{},
 	); err != nil {
@@ -250,6 +258,10 @@ func main() {
 	e.Use(echomiddleware.CORSWithConfig(corsConfig))
 
 	e.Use(echomiddleware.CSRFWithConfig(echomiddleware.CSRFConfig{
+		Skipper: func(c echo.Context) bool {
+			// Provider webhooks authenticate with signatures instead of CSRF tokens
+			return strings.HasPrefix(c.Request().URL.Path, "/webhooks/")
+		},
 		TokenLookup:    "header:X-CSRF-Token,form:_csrf",
 		CookieName:     "_csrf",
 		CookieSecure:   cfg.Environment == "production",
@@ -309,6 +321,9 @@ func main() {
 	e.GET("/compliance", handlers.WebsiteComplianceHandler)
 	e.POST("/api/website/contact", handlers.WebsiteContactSubmitHandler, middleware.PublicFormRateLimiter.Middleware())
 
+	// Provider webhooks (signature-verified)
+	e.POST("/webhooks/resend", handlers.ResendWebhookHandler)
+
 	firmSetup := e.Group("/firm")
 	firmSetup.Use(middleware.RequireAuth())
 	{
@@ -360,6 +375,7 @@ func main() {
 	protected.Use(middleware.RequireAuth())
 	protected.Use(middleware.RequireFirm())
 	protected.Use(middleware.AuditContext())
+	protected.Use(middleware.RestrictExternalUsers())
 	{
 		protected.GET("/dashboard", handlers.DashboardHandler)
 		protected.GET("/api/notifications", handlers.GetNotificationsHandler)
@@ -385,6 +401,10 @@ func main() {
 			adminRoutes.POST("/api/firm/logo", handlers.UploadFirmLogoHandler)
 			adminRoutes.DELETE("/api/firm/logo", handlers.DeleteFirmLogoHandler)
 			adminRoutes.GET("/api/firm/settings/billing", handlers.FirmBillingTabHandler)
+			adminRoutes.GET("/api/firm/roles", handlers.GetFirmRolesTabHandler)
+			adminRoutes.POST("/api/firm/roles", handlers.CreateFirmRoleHandler)
+			adminRoutes.PUT("/api/firm/roles/:id", handlers.UpdateFirmRoleHandler)
+			adminRoutes.DELETE("/api/firm/roles/:id", handlers.DeleteFirmRoleHandler)
 			adminRoutes.POST("/api/addons/purchase", handlers.PurchaseAddOnHandler)
 			adminRoutes.DELETE("/api/addons/:id", handlers.CancelAddOnHandler)
 			adminRoutes.GET("/audit-logs", handlers.AuditLogsPageHandler)
@@ -404,7 +424,7 @@ func main() {
 
 		// Consent routes (All authenticated users)
 		userRoutes := protected.Group("")
-		userRoutes.Use(middleware.RequireRole("user", "lawyer", "admin"))
+		userRoutes.Use(middleware.RequireCapability(models.CapabilityUsersView))
 		{
 			userRoutes.GET("/users", handlers.UsersPageHandler)
 			userRoutes.GET("/api/users", handlers.GetUsers)
@@ -428,9 +448,11 @@ func main() {
 			complianceRoutes.POST("/arco/:id/resolve", handlers.ResolveComplianceARCORequestHandler)
 			complianceRoutes.GET("/audit", handlers.GetComplianceAuditLogsHandler)
 			complianceRoutes.GET("/export", handlers.ExportComplianceUserDataHandler)
+			complianceRoutes.GET("/holds", handlers.GetComplianceLegalHoldsHandler)
+			complianceRoutes.GET("/holds/export", handlers.ExportComplianceLegalHoldsHandler)
 		}
 		templateRoutes := protected.Group("/templates")
-		templateRoutes.Use(middleware.RequireRole("admin", "lawyer"))
+		templateRoutes.Use(middleware.RequireCapability(models.CapabilityTemplatesManage))
 		{
 			templateRoutes.GET("", handlers.TemplatesPageHandler)
 			templateRoutes.GET("/new", handlers.TemplateEditorPageHandler)
@@ -438,7 +460,7 @@ func main() {
 		}
 
 		templateApiRoutes := protected.Group("/api/templates")
-		templateApiRoutes.Use(middleware.RequireRole("admin", "lawyer"))
+		templateApiRoutes.Use(middleware.RequireCapability(models.CapabilityTemplatesManage))
 		{
 			templateApiRoutes.GET("", handlers.GetTemplatesHandler)
 			templateApiRoutes.POST("", handlers.CreateTemplateHandler)
@@ -449,6 +471,8 @@ func main() {
 			templateApiRoutes.GET("/:id/clone/modal", handlers.GetCloneTemplateModalHandler)
 			templateApiRoutes.POST("/:id/clone", handlers.CloneTemplateHandler)
 			templateApiRoutes.GET("/variables", handlers.GetTemplateVariablesHandler)
+			templateApiRoutes.GET("/:id/preview", handlers.GetTemplatePreviewPanelHandler)
+			templateApiRoutes.POST("/:id/preview", handlers.PreviewTemplateContentHandler)
 			templateApiRoutes.GET("/categories", handlers.GetCategoriesHandler)
 			templateApiRoutes.POST("/categories", handlers.CreateCategoryHandler)
 			templateApiRoutes.PUT("/categories/:id", handlers.UpdateCategoryHandler)
@@ -458,16 +482,25 @@ func main() {
 		protected.GET("/api/subtypes/branches", handlers.GetBranchesForDomainHandler)
 		protected.GET("/api/subtypes/options", handlers.GetSubtypeOptionsHandler)
 
+		// External collaborator portal (constrained accounts, one shared case at a time)
+		externalRoutes := protected.Group("/external")
+		externalRoutes.Use(middleware.RequireRole("external"))
+		{
+			externalRoutes.GET("", handlers.ExternalPortalHandler)
+			externalRoutes.GET("/cases/:id", handlers.ExternalCaseHandler)
+			externalRoutes.GET("/cases/:id/documents/:docId/download", handlers.ExternalDocumentDownloadHandler)
+		}
+
 		protected.GET("/cases", handlers.CasesPageHandler)
 		protected.GET("/cases/:id", handlers.GetCaseDetailHandler)
 
 		searchRoutes := protected.Group("/api")
-		searchRoutes.Use(middleware.RequireRole("admin", "lawyer", "staff"))
+		searchRoutes.Use(middleware.RequireCapability(models.CapabilitySearch))
 		{
 			searchRoutes.GET("/search", handlers.SearchCasesHandler)
 		}
 		clientCaseRoutes := protected.Group("/api/cases")
-		clientCaseRoutes.Use(middleware.RequireRole("admin", "lawyer", "client"))
+		clientCaseRoutes.Use(middleware.RequireCapability(models.CapabilityCasesView))
 		{
 			clientCaseRoutes.GET("", handlers.GetCasesHandler)
 			clientCaseRoutes.GET("/:id/documents", handlers.GetCaseDocumentsHandler)
@@ -477,7 +510,7 @@ func main() {
 			clientCaseRoutes.GET("/:id/judicial-view", handlers.GetJudicialProcessViewHandler)
 		}
 		caseRoutes := protected.Group("/api/cases")
-		caseRoutes.Use(middleware.RequireRole("admin", "lawyer"))
+		caseRoutes.Use(middleware.RequireCapability(models.CapabilityCasesManage))
 		{
 			caseRoutes.GET("/new", handlers.CreateCaseModalHandler)
 			caseRoutes.POST("", handlers.CreateCaseHandler)
@@ -511,10 +544,30 @@ func main() {
 			caseRoutes.POST("/history", handlers.CreateHistoricalCaseHandler)
 			caseRoutes.GET("/history/branches", handlers.GetHistoricalCaseBranchesHandler)
 			caseRoutes.GET("/history/subtypes", handlers.GetHistoricalCaseSubtypesHandler)
+			caseRoutes.GET("/:id/approval", handlers.GetCaseApprovalHandler)
+			caseRoutes.GET("/:id/communications", handlers.GetCaseCommunicationsHandler)
+			caseRoutes.GET("/:id/external", handlers.GetCaseExternalAccessHandler)
+			caseRoutes.POST("/:id/external", handlers.InviteExternalCollaboratorHandler)
+			caseRoutes.DELETE("/:id/external/:accessId", handlers.RevokeExternalAccessHandler)
+			caseRoutes.PATCH("/:id/documents/:docId/external-share", handlers.ToggleDocumentExternalShareHandler)
+			caseRoutes.GET("/:id/documents/:docId/annotations", handlers.GetDocumentAnnotationsHandler)
+			caseRoutes.POST("/:id/documents/:docId/annotations", handlers.CreateDocumentAnnotationHandler)
+			caseRoutes.PATCH("/:id/documents/:docId/annotations/:annotationId", handlers.UpdateDocumentAnnotationHandler)
+			caseRoutes.DELETE("/:id/documents/:docId/annotations/:annotationId", handlers.DeleteDocumentAnnotationHandler)
+			caseRoutes.GET("/:id/legal-holds", handlers.GetCaseLegalHoldsHandler)
+			caseRoutes.POST("/:id/legal-holds", handlers.PlaceLegalHoldHandler)
+			caseRoutes.POST("/:id/legal-holds/:holdId/release", handlers.ReleaseLegalHoldHandler, middleware.RequireRole("admin"))
+		}
+
+		// Intake approval decisions (Admin only)
+		caseApprovalRoutes := protected.Group("/api/cases")
+		caseApprovalRoutes.Use(middleware.RequireRole("admin"))
+		{
+			caseApprovalRoutes.POST("/:id/approval", handlers.DecideCaseApprovalHandler)
 		}
 
 		caseShared := protected.Group("/api/cases")
-		caseShared.Use(middleware.RequireRole("admin", "lawyer", "client"))
+		caseShared.Use(middleware.RequireCapability(models.CapabilityCasesView))
 		{
 			caseShared.GET("/:id/summary", handlers.GetCaseSummaryHandler)
 			caseShared.GET("/:id/timeline", handlers.GetCaseTimelineHandler)
@@ -535,7 +588,7 @@ func main() {
 
 		// Services Routes (Shared: Admin, Lawyer, Client)
 		serviceShared := protected.Group("/api/services")
-		serviceShared.Use(middleware.RequireRole("admin", "lawyer", "client"))
+		serviceShared.Use(middleware.RequireCapability(models.CapabilityServicesView))
 		{
 			serviceShared.GET("", handlers.GetServicesHandler)
 			serviceShared.GET("/:id", handlers.GetServiceHandler)
@@ -550,7 +603,7 @@ func main() {
 
 		// Services Routes (Admin/Lawyer Only)
 		serviceAdmin := protected.Group("/api/services")
-		serviceAdmin.Use(middleware.RequireRole("admin", "lawyer"))
+		serviceAdmin.Use(middleware.RequireCapability(models.CapabilityServicesManage))
 		{
 			// Service CRUD
 			serviceAdmin.GET("/new", handlers.CreateServiceModalHandler)
@@ -586,6 +639,21 @@ func main() {
 			serviceAdmin.POST("/:id/generate", handlers.GenerateServiceDocumentHandler)
 		}
 
+		// Contract Renewal Routes
+		protected.GET("/contracts", handlers.ContractsPageHandler, middleware.RequireCapability(models.CapabilityContractsView))
+		protected.GET("/api/contracts", handlers.GetContractRemindersHandler, middleware.RequireCapability(models.CapabilityContractsView))
+
+		contractAdmin := protected.Group("/api/contracts")
+		contractAdmin.Use(middleware.RequireCapability(models.CapabilityContractsManage))
+		{
+			contractAdmin.GET("/new", handlers.ContractReminderFormModalHandler)
+			contractAdmin.POST("", handlers.CreateContractReminderHandler)
+			contractAdmin.GET("/:id/edit", handlers.ContractReminderFormModalHandler)
+			contractAdmin.PUT("/:id", handlers.UpdateContractReminderHandler)
+			contractAdmin.PATCH("/:id/status", handlers.UpdateContractReminderStatusHandler)
+			contractAdmin.DELETE("/:id", handlers.DeleteContractReminderHandler)
+		}
+
 		protected.GET("/historical-cases", handlers.HistoricalCasesPageHandler)
 		protected.GET("/tools", handlers.ToolsPageHandler)
 
@@ -599,18 +667,21 @@ func main() {
 
 		// Filing Number Builder Tool API (admin/lawyer only)
 		filingNumberRoutes := protected.Group("/api/tools/filing-number")
-		filingNumberRoutes.Use(middleware.RequireRole("admin", "lawyer"))
+		filingNumberRoutes.Use(middleware.RequireCapability(models.CapabilityCasesManage))
 		{
 			filingNumberRoutes.POST("/build", handlers.BuildFilingNumberHandler)
 			filingNumberRoutes.POST("/parse", handlers.ParseFilingNumberHandler)
 		}
 
+		// Public registry prefill for client and opposing party forms (admin/lawyer only)
+		protected.GET("/api/registry/lookup", handlers.RegistryLookupHandler, middleware.RequireCapability(models.CapabilityCasesManage))
+
 		// Report Generator Tool API
-		protected.POST("/tools/export", handlers.ExportReportHandler, middleware.RequireRole("admin", "lawyer"))
+		protected.POST("/tools/export", handlers.ExportReportHandler, middleware.RequireCapability(models.CapabilityReportsExport))
 
 		adminRoutes.GET("/api/lawyers", handlers.GetLawyersForFilterHandler)
 		availabilityRoutes := protected.Group("")
-		availabilityRoutes.Use(middleware.RequireRole("admin", "lawyer"))
+		availabilityRoutes.Use(middleware.RequireCapability(models.CapabilityCalendarManage))
 		{
 			availabilityRoutes.GET("/availability", handlers.AvailabilityPageHandler)
 			availabilityRoutes.GET("/api/availability", handlers.GetAvailabilityHandler)
@@ -626,9 +697,10 @@ func main() {
 		adminRoutes.PUT("/api/firm/buffer-settings", handlers.UpdateBufferSettingsHandler)
 		protected.GET("/calendar", handlers.CalendarPageHandler)
 		protected.GET("/api/calendar/events", handlers.CalendarEventsHandler)
+		protected.GET("/api/calendar/agenda", handlers.CalendarAgendaPDFHandler, middleware.RequireCapability(models.CapabilityCalendarView))
 		protected.GET("/appointments", handlers.AppointmentsPageHandler)
 		appointmentRoutes := protected.Group("/api/appointments")
-		appointmentRoutes.Use(middleware.RequireRole("admin", "lawyer"))
+		appointmentRoutes.Use(middleware.RequireCapability(models.CapabilityCalendarManage))
 		{
 			appointmentRoutes.GET("", handlers.GetAppointmentsHandler)
 			appointmentRoutes.GET("/slots", handlers.GetAvailableSlotsHandler)
@@ -668,6 +740,10 @@ func main() {
 			if err := services.ExpireAddOns(db.DB); err != nil {
 				log.Printf("Error expiring add-ons: %v", err)
 			}
+
+			if err := services.ReleaseExpiredSeats(db.DB); err != nil {
+				log.Printf("Error releasing expired seats: %v", err)
+			}
 		}
 	}()
 
@@ -25,6 +25,11 @@ type Config struct {
 	EmailFrom     string
 	EmailFromName string
 	EmailTestMode bool // When true, emails are logged to console instead of sent
+	// Sender on a Resend domain with open/click tracking turned on, used only for firms that
+	// opted in. The EmailFrom domain must keep tracking off
+	EmailTrackingFrom string
+	// Signing secret for Resend delivery/open/click webhooks
+	ResendWebhookKey string
 	// Other
 	AllowedOrigins   []string
 	AppURL           string
@@ -69,6 +74,8 @@ func Load() *Config {
 		EmailFrom:          getEnv("EMAIL_FROM", "noreply@lexlegalcloud.org"),
 		EmailFromName:      getEnv("EMAIL_FROM_NAME", "lexlegalcloud App"),
 		EmailTestMode:      getEnvBool("EMAIL_TEST_MODE", true), // Default true for safety
+		EmailTrackingFrom:  getEnv("EMAIL_TRACKING_FROM", ""),
+		ResendWebhookKey:   getEnv("RESEND_WEBHOOK_SECRET", ""),
 		AllowedOrigins:     strings.Split(getEnv("ALLOWED_ORIGINS", "*"), ","),
 		AppURL:             getEnv("APP_URL", "http://localhost:8080"),
 		SessionSecret:      sessionSecret,
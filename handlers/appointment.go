@@ -288,7 +288,12 @@ func CreateAppointmentHandler(c echo.Context) error {
 		})
 	}
 
-	services.SendEmailAsync(cfg, clientEmail)
+	services.SendLoggedEmailAsync(db.DB, cfg, clientEmail, services.EmailLogEntry{
+		FirmID:      apt.FirmID,
+		RecipientID: &client.ID,
+		CaseID:      apt.CaseID,
+		Category:    models.EmailCategoryAppointmentConfirmation,
+	})
 
 	// Notify lawyer about new appointment
 	lawyerEmailData := services.LawyerAppointmentNotificationEmailData{
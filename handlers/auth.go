@@ -205,6 +205,15 @@ func LoginPostHandler(c echo.Context) error {
 		return c.Redirect(http.StatusSeeOther, "/firm/setup")
 	}
 
+	// External collaborators only have the shared-case portal
+	if user.IsExternal() {
+		if c.Request().Header.Get("HX-Request") == "true" {
+			c.Response().Header().Set("HX-Redirect", middleware.ExternalPortalPath)
+			return c.NoContent(http.StatusOK)
+		}
+		return c.Redirect(http.StatusSeeOther, middleware.ExternalPortalPath)
+	}
+
 	// Redirect to dashboard
 	if c.Request().Header.Get("HX-Request") == "true" {
 		c.Response().Header().Set("HX-Redirect", "/dashboard")
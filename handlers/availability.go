@@ -31,8 +31,8 @@ func AvailabilityPageHandler(c echo.Context) error {
 	currentUser := middleware.GetCurrentUser(c)
 	firm := middleware.GetCurrentFirm(c)
 
-	// Check if user is a lawyer or admin
-	if currentUser.Role != "lawyer" && currentUser.Role != "admin" {
+	// Check if user manages calendars (lawyers and admins)
+	if !currentUser.Can(models.CapabilityCalendarManage) {
 		return echo.NewHTTPError(http.StatusForbidden, "Only lawyers and admins can access availability settings")
 	}
 
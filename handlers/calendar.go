@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"law_flow_app_go/db"
 	"law_flow_app_go/middleware"
 	"law_flow_app_go/models"
 	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
 	"law_flow_app_go/templates/pages"
 	"net/http"
 	"time"
@@ -24,7 +26,16 @@ func CalendarPageHandler(c echo.Context) error {
 	firm := middleware.GetCurrentFirm(c)
 	csrfToken := middleware.GetCSRFToken(c)
 
-	component := pages.CalendarPage(c.Request().Context(), user, firm, csrfToken, aptTypes)
+	// Users who see the firm's calendar may print the agenda of any lawyer
+	var lawyers []models.User
+	if user.Can(models.CapabilityCalendarFirm) {
+		db.DB.Select("id", "name").
+			Where("firm_id = ? AND is_active = ? AND role IN ?", firm.ID, true, []string{"admin", "lawyer"}).
+			Order("name ASC").
+			Find(&lawyers)
+	}
+
+	component := pages.CalendarPage(c.Request().Context(), user, firm, csrfToken, aptTypes, lawyers)
 	return component.Render(c.Request().Context(), c.Response().Writer)
 }
 
@@ -68,7 +79,7 @@ func CalendarEventsHandler(c echo.Context) error {
 	var appointments []models.Appointment
 	var dbErr error
 
-	if user.Role == "admin" || user.Role == "staff" {
+	if user.Can(models.CapabilityCalendarFirm) {
 		appointments, dbErr = services.GetFirmAppointments(db.DB, *user.FirmID, startTime, endTime)
 	} else {
 		appointments, dbErr = services.GetLawyerAppointments(db.DB, user.ID, startTime, endTime)
@@ -118,3 +129,58 @@ func CalendarEventsHandler(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, events)
 }
+
+// CalendarAgendaPDFHandler renders a lawyer's daily or weekly agenda as a printable PDF.
+// Lawyers print their own agenda; admins and staff may pick any lawyer of the firm.
+func CalendarAgendaPDFHandler(c echo.Context) error {
+	user := middleware.GetCurrentUser(c)
+	firm := middleware.GetCurrentFirm(c)
+
+	period := c.QueryParam("period")
+	if period == "" {
+		period = services.AgendaPeriodDay
+	}
+
+	// The requested date is a calendar date of the firm's timezone
+	date := time.Now()
+	if dateStr := c.QueryParam("date"); dateStr != "" {
+		loc, err := time.LoadLocation(firm.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		date, err = time.ParseInLocation("2006-01-02", dateStr, loc)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid date format")
+		}
+	}
+
+	lawyer := user
+	if lawyerID := c.QueryParam("lawyer_id"); lawyerID != "" && lawyerID != user.ID {
+		if !user.Can(models.CapabilityCalendarFirm) {
+			return echo.NewHTTPError(http.StatusForbidden, "You can only print your own agenda")
+		}
+		var selected models.User
+		if err := middleware.GetFirmScopedQuery(c, db.DB).
+			Where("id = ? AND role IN ?", lawyerID, []string{"admin", "lawyer"}).
+			First(&selected).Error; err != nil {
+			return echo.NewHTTPError(http.StatusNotFound, "Lawyer not found")
+		}
+		lawyer = &selected
+	}
+
+	agenda, err := services.BuildLawyerAgenda(db.DB, firm, lawyer, date, period)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAgendaPeriod) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to build agenda")
+	}
+
+	pdf, err := services.GenerateAgendaPDF(agenda, i18n.GetLocale(c.Request().Context()))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate agenda PDF")
+	}
+
+	c.Response().Header().Set("Content-Disposition", "inline; filename=\""+agenda.FileName()+"\"")
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
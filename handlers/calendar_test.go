@@ -80,3 +80,40 @@ func TestGetCalendarEventsHandler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, err.(*echo.HTTPError).Code)
 	})
 }
+
+func TestCalendarAgendaAccess(t *testing.T) {
+	database := setupTestDB(t)
+	firm := &models.Firm{ID: "firm-cal-agenda", Name: "Agenda Firm", Timezone: "America/Bogota"}
+	database.Create(firm)
+	lawyer := &models.User{ID: "lawyer-cal-agenda", Name: "Agenda Lawyer", Email: "lawyer-cal-agenda@test.com", FirmID: stringToPtr(firm.ID), Role: "lawyer", IsActive: true}
+	database.Create(lawyer)
+	other := &models.User{ID: "other-cal-agenda", Name: "Other Agenda Lawyer", Email: "other-cal-agenda@test.com", FirmID: stringToPtr(firm.ID), Role: "lawyer", IsActive: true}
+	database.Create(other)
+
+	t.Run("Lawyer cannot print another lawyer's agenda", func(t *testing.T) {
+		_, c, _ := setupEcho(http.MethodGet, "/api/calendar/agenda.pdf?lawyer_id="+other.ID, nil)
+		c.Set("user", lawyer)
+		c.Set("firm", firm)
+
+		err := CalendarAgendaPDFHandler(c)
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("Custom role with firm calendar lists every lawyer", func(t *testing.T) {
+		role := &models.FirmRole{FirmID: firm.ID, Name: "Secretary", BaseRole: "lawyer",
+			Capabilities: models.CapabilityCalendarView + "," + models.CapabilityCalendarFirm}
+		database.Create(role)
+		secretary := &models.User{ID: "secretary-cal-agenda", Name: "Secretary", Email: "secretary-cal-agenda@test.com", FirmID: stringToPtr(firm.ID), Role: "lawyer", IsActive: true, CustomRoleID: &role.ID, CustomRole: role}
+
+		_, c, rec := setupEcho(http.MethodGet, "/calendar", nil)
+		c.Set("user", secretary)
+		c.Set("firm", firm)
+
+		err := CalendarPageHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Other Agenda Lawyer")
+	})
+}
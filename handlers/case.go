@@ -6,10 +6,10 @@ import (
 	"law_flow_app_go/middleware"
 	"law_flow_app_go/models"
 	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
 	"law_flow_app_go/templates/pages"
 	"law_flow_app_go/templates/partials"
 	"net/http"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -78,7 +78,7 @@ func GetCasesHandler(c echo.Context) error {
 	}
 
 	// Apply status filter
-	if status != "" && models.IsValidCaseStatus(status) {
+	if status != "" && (models.IsValidCaseStatus(status) || status == models.CaseStatusPendingApproval) {
 		query = query.Where("status = ?", status)
 	}
 
@@ -297,6 +297,14 @@ func GetCaseDocumentsHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch documents")
 	}
 
+	held, err := services.GetHeldDocumentIDs(db.DB, caseID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check legal holds")
+	}
+	for i := range documents {
+		documents[i].UnderLegalHold = held[documents[i].ID]
+	}
+
 	// Check if HTMX request
 	if c.Request().Header.Get("HX-Request") == "true" {
 		component := partials.CaseDocumentTable(c.Request().Context(), documents, page, totalPages, limit, int(total), caseID)
@@ -315,8 +323,9 @@ func GetCaseDocumentsHandler(c echo.Context) error {
 	})
 }
 
-// DownloadCaseDocumentHandler serves a case document for download
-func DownloadCaseDocumentHandler(c echo.Context) error {
+// getCaseDocumentFile fetches a case document the current user may access, ensuring its file
+// can be served
+func getCaseDocumentFile(c echo.Context) (*models.CaseDocument, error) {
 	caseID := c.Param("id")
 	docID := c.Param("docId")
 	currentUser := middleware.GetCurrentUser(c)
@@ -331,19 +340,31 @@ func DownloadCaseDocumentHandler(c echo.Context) error {
 
 	var caseRecord models.Case
 	if err := caseQuery.First(&caseRecord, "id = ?", caseID).Error; err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Case not found")
 	}
 
 	// Fetch document with firm-scoping
 	var document models.CaseDocument
 	query := middleware.GetFirmScopedQuery(c, db.DB)
 	if err := query.First(&document, "id = ? AND case_id = ?", docID, caseID).Error; err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Document not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Document not found")
 	}
 
 	// Check if file exists
 	if document.FilePath == "" {
-		return echo.NewHTTPError(http.StatusNotFound, "No file attached to this document")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "No file attached to this document")
+	}
+	if document.IsFileMissing() && services.FileStillMissing(db.DB, services.Storage, &document, document.FilePath) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, i18n.T(c.Request().Context(), "storage.file_missing_hint"))
+	}
+	return &document, nil
+}
+
+// DownloadCaseDocumentHandler serves a case document for download
+func DownloadCaseDocumentHandler(c echo.Context) error {
+	document, err := getCaseDocumentFile(c)
+	if err != nil {
+		return err
 	}
 
 	// Audit logging (Download)
@@ -360,70 +381,14 @@ func DownloadCaseDocumentHandler(c echo.Context) error {
 		nil,
 	)
 
-	// Check if using R2 storage (file path is a storage key, not a local path)
-	if _, ok := services.Storage.(*services.R2Storage); ok {
-		// Generate signed URL for R2 download (valid for 15 minutes)
-		signedURL, err := services.Storage.GetSignedURL(context.Background(), document.FilePath, 15*time.Minute)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate download URL")
-		}
-		return c.Redirect(http.StatusTemporaryRedirect, signedURL)
-	}
-
-	// Local storage: verify file path is within upload directory (security check)
-	uploadDir := "uploads"
-	absUploadDir, err := filepath.Abs(uploadDir)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify file path")
-	}
-	localPath := filepath.Join(uploadDir, document.FilePath)
-	absFilePath, err := filepath.Abs(localPath)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify file path")
-	}
-	if !strings.HasPrefix(absFilePath, absUploadDir) {
-		return echo.NewHTTPError(http.StatusForbidden, "Invalid file path")
-	}
-
-	// Set the Content-Disposition header to suggest the original filename
-	c.Response().Header().Set("Content-Disposition", "attachment; filename=\""+document.FileOriginalName+"\"")
-	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
-	c.Response().Header().Set("X-Download-Options", "noopen")
-	c.Response().Header().Set("X-Permitted-Cross-Domain-Policies", "none")
-
-	// Serve file from local storage
-	return c.File(localPath)
+	return deliverStoredDocument(c, middleware.GetCurrentFirm(c), document.FilePath, document.FileOriginalName)
 }
 
-// ViewCaseDocumentHandler serves a PDF document for inline viewing
+// ViewCaseDocumentHandler streams a PDF document for inline viewing
 func ViewCaseDocumentHandler(c echo.Context) error {
-	caseID := c.Param("id")
-	docID := c.Param("docId")
-	currentUser := middleware.GetCurrentUser(c)
-
-	// First verify the case exists and user has access
-	caseQuery := middleware.GetFirmScopedQuery(c, db.DB)
-	if currentUser.Role == "lawyer" {
-		caseQuery = caseQuery.Where("assigned_to_id = ?", currentUser.ID)
-	} else if currentUser.Role == "client" {
-		caseQuery = caseQuery.Where("client_id = ?", currentUser.ID)
-	}
-
-	var caseRecord models.Case
-	if err := caseQuery.First(&caseRecord, "id = ?", caseID).Error; err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
-	}
-
-	// Fetch document with firm-scoping
-	var document models.CaseDocument
-	query := middleware.GetFirmScopedQuery(c, db.DB)
-	if err := query.First(&document, "id = ? AND case_id = ?", docID, caseID).Error; err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Document not found")
-	}
-
-	// Check if file exists
-	if document.FilePath == "" {
-		return echo.NewHTTPError(http.StatusNotFound, "No file attached to this document")
+	document, err := getCaseDocumentFile(c)
+	if err != nil {
+		return err
 	}
 
 	// Validate it's a PDF
@@ -445,20 +410,7 @@ func ViewCaseDocumentHandler(c echo.Context) error {
 		nil,
 	)
 
-	// Get file from storage (works for both R2 and local)
-	reader, contentType, err := services.Storage.Get(context.Background(), document.FilePath)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve file")
-	}
-	defer reader.Close()
-
-	// Set headers for inline PDF display
-	c.Response().Header().Set("Content-Type", contentType)
-	c.Response().Header().Set("Content-Disposition", "inline; filename=\""+document.FileOriginalName+"\"")
-	c.Response().Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
-
-	// Stream the file to the response
-	return c.Stream(http.StatusOK, contentType, reader)
+	return streamStoredDocument(c, document.FilePath, document.FileOriginalName, true)
 }
 
 // UploadCaseDocumentHandler handles document uploads for a case
@@ -624,8 +576,8 @@ func ToggleDocumentVisibilityHandler(c echo.Context) error {
 	docID := c.Param("docId")
 	currentUser := middleware.GetCurrentUser(c)
 
-	// Only admin and lawyer can toggle visibility
-	if currentUser.Role != "admin" && currentUser.Role != "lawyer" {
+	// Only users who manage cases can toggle visibility
+	if !currentUser.Can(models.CapabilityCasesManage) {
 		if c.Request().Header.Get("HX-Request") == "true" {
 			return c.HTML(http.StatusForbidden, `<div class="p-4 bg-red-500/20 text-red-400 rounded-lg">Permission denied</div>`)
 		}
@@ -674,6 +626,7 @@ func ToggleDocumentVisibilityHandler(c echo.Context) error {
 	if c.Request().Header.Get("HX-Request") == "true" {
 		// Preload uploader for display
 		db.DB.Preload("UploadedBy").First(&document, "id = ?", docID)
+		document.UnderLegalHold, _ = services.IsDocumentUnderLegalHold(db.DB, document.ID)
 		component := partials.CaseDocumentRow(c.Request().Context(), document, caseID)
 		return component.Render(c.Request().Context(), c.Response().Writer)
 	}
@@ -778,6 +731,7 @@ func CreateCaseHandler(c echo.Context) error {
 	filingNumber := c.FormValue("filing_number")
 	description := c.FormValue("description")
 	assignedToID := c.FormValue("assigned_to_id")
+	amountStr := strings.TrimSpace(c.FormValue("amount_in_dispute"))
 
 	// Classification
 	domainID := c.FormValue("domain_id")
@@ -800,6 +754,15 @@ func CreateCaseHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Description must be less than 5000 characters")
 	}
 
+	var amountInDispute *float64
+	if amountStr != "" {
+		amount, err := strconv.ParseFloat(amountStr, 64)
+		if err != nil || amount < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid amount in dispute")
+		}
+		amountInDispute = &amount
+	}
+
 	// Generate unique case number
 	caseNumber, err := services.EnsureUniqueCaseNumber(db.DB, currentFirm.ID)
 	if err != nil {
@@ -821,6 +784,7 @@ func CreateCaseHandler(c echo.Context) error {
 		StatusChangedAt: &now,
 		DomainID:        &domainID,
 		BranchID:        &branchID,
+		AmountInDispute: amountInDispute,
 	}
 
 	if title != "" {
@@ -836,6 +800,26 @@ func CreateCaseHandler(c echo.Context) error {
 		newCase.AssignedToID = &assignedToID
 	}
 
+	// High-risk intakes stay pending until a second admin approves them
+	approvalCheck, err := services.EvaluateIntakeApproval(db.DB, currentFirm, &newCase)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to evaluate intake approval")
+	}
+	// A firm whose only admin is the requester has nobody to approve: the case opens as usual
+	// and the skipped check is recorded in the audit log
+	approvalSkipped := false
+	if approvalCheck.Required() {
+		hasApprover, err := services.HasCaseApprover(db.DB, currentFirm.ID, currentUser.ID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to evaluate intake approval")
+		}
+		if hasApprover {
+			newCase.Status = models.CaseStatusPendingApproval
+		} else {
+			approvalSkipped = true
+		}
+	}
+
 	tx := db.DB.Begin()
 
 	if err := tx.Create(&newCase).Error; err != nil {
@@ -862,6 +846,15 @@ func CreateCaseHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create default milestones")
 	}
 
+	var approval *models.CaseApproval
+	if approvalCheck.Required() && !approvalSkipped {
+		approval, err = services.RequestCaseApproval(tx, &newCase, currentUser.ID, approvalCheck)
+		if err != nil {
+			tx.Rollback()
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to request case approval")
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to commit case")
 	}
@@ -885,6 +878,16 @@ func CreateCaseHandler(c echo.Context) error {
 		nil,
 		newCase,
 	)
+	if approval != nil {
+		services.LogAuditEvent(db.DB, auditCtx, models.AuditActionCreate,
+			"CaseApproval", approval.ID, newCase.CaseNumber,
+			"Intake approval requested: "+approval.Reasons, nil, approval)
+	}
+	if approvalSkipped {
+		services.LogAuditEvent(db.DB, auditCtx, models.AuditActionCreate,
+			"Case", newCase.ID, newCase.CaseNumber,
+			"Intake approval skipped, no other active admin: "+strings.Join(approvalCheck.Reasons, ","), nil, nil)
+	}
 
 	// Trigger reload of table via HTMX header
 	c.Response().Header().Set("HX-Trigger", "reload-cases")
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"law_flow_app_go/templates/partials"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetCaseApprovalHandler renders the intake approval banner for a pending case
+func GetCaseApprovalHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	approval, err := services.GetPendingCaseApproval(db.DB, currentFirm.ID, caseRecord.ID)
+	if err != nil {
+		if errors.Is(err, services.ErrCaseApprovalNotFound) {
+			return c.NoContent(http.StatusOK)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch approval")
+	}
+
+	canDecide := currentUser.Role == "admin" && currentUser.ID != approval.RequestedByID
+	component := partials.CaseApprovalBanner(c.Request().Context(), *caseRecord, *approval, canDecide)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
+
+// DecideCaseApprovalHandler approves or rejects a case awaiting intake approval (admin only)
+func DecideCaseApprovalHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	decision := c.FormValue("decision")
+	if decision != "approve" && decision != "reject" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid decision")
+	}
+	notes := strings.TrimSpace(c.FormValue("notes"))
+	if len(notes) > 2000 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Notes must be less than 2000 characters")
+	}
+	if decision == "reject" && notes == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "A reason is required to reject a case")
+	}
+
+	approval, err := services.GetPendingCaseApproval(db.DB, currentFirm.ID, caseRecord.ID)
+	if err != nil {
+		if errors.Is(err, services.ErrCaseApprovalNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "No pending approval for this case")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch approval")
+	}
+
+	if err := services.DecideCaseApproval(db.DB, approval, currentUser, decision == "approve", notes); err != nil {
+		switch {
+		case errors.Is(err, services.ErrCaseApprovalSelf), errors.Is(err, services.ErrCaseApprovalForbidden):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrCaseApprovalNotPending):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to record decision")
+	}
+
+	description := "Intake approval granted"
+	if decision == "reject" {
+		description = "Intake approval rejected"
+	}
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionUpdate,
+		"CaseApproval", approval.ID, caseRecord.CaseNumber,
+		description, map[string]string{"status": models.CaseApprovalStatusPending},
+		map[string]string{"status": approval.Status, "notes": notes})
+
+	c.Response().Header().Set("HX-Refresh", "true")
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// getAnnotatableDocument fetches a PDF of a case the current user works on
+func getAnnotatableDocument(c echo.Context) (*models.CaseDocument, error) {
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	var document models.CaseDocument
+	query := middleware.GetFirmScopedQuery(c, db.DB)
+	if err := query.First(&document, "id = ? AND case_id = ?", c.Param("docId"), caseRecord.ID).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Document not found")
+	}
+	if !strings.HasSuffix(strings.ToLower(document.FileOriginalName), ".pdf") {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Only PDF files can be annotated")
+	}
+	return &document, nil
+}
+
+// annotationHTTPError maps annotation service errors to an HTTP error
+func annotationHTTPError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrAnnotationNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Annotation not found")
+	case errors.Is(err, services.ErrInvalidAnnotationType),
+		errors.Is(err, services.ErrInvalidAnnotationPage),
+		errors.Is(err, services.ErrInvalidAnnotationRects),
+		errors.Is(err, services.ErrAnnotationCommentRequired):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save annotation")
+}
+
+// GetDocumentAnnotationsHandler lists the annotations the current user can see on a case PDF
+func GetDocumentAnnotationsHandler(c echo.Context) error {
+	document, err := getAnnotatableDocument(c)
+	if err != nil {
+		return err
+	}
+
+	annotations, err := services.GetDocumentAnnotations(db.DB, document.ID, middleware.GetCurrentUser(c).ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch annotations")
+	}
+	return c.JSON(http.StatusOK, annotations)
+}
+
+// CreateDocumentAnnotationHandler adds a highlight, comment or page pin to a case PDF
+func CreateDocumentAnnotationHandler(c echo.Context) error {
+	document, err := getAnnotatableDocument(c)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Type         string                 `json:"type"`
+		Page         int                    `json:"page"`
+		Rects        models.AnnotationRects `json:"rects"`
+		Color        string                 `json:"color"`
+		SelectedText string                 `json:"selected_text"`
+		Comment      string                 `json:"comment"`
+		IsShared     bool                   `json:"is_shared"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	annotation, err := services.CreateDocumentAnnotation(db.DB, document, middleware.GetCurrentUser(c).ID, services.AnnotationInput{
+		Type:         req.Type,
+		Page:         req.Page,
+		Rects:        req.Rects,
+		Color:        req.Color,
+		SelectedText: req.SelectedText,
+		Comment:      req.Comment,
+		IsShared:     req.IsShared,
+	})
+	if err != nil {
+		return annotationHTTPError(err)
+	}
+	return c.JSON(http.StatusCreated, annotation)
+}
+
+// UpdateDocumentAnnotationHandler edits the comment, color or sharing of the user's own annotation
+func UpdateDocumentAnnotationHandler(c echo.Context) error {
+	document, err := getAnnotatableDocument(c)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Comment  *string `json:"comment"`
+		Color    *string `json:"color"`
+		IsShared *bool   `json:"is_shared"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	annotation, err := services.UpdateDocumentAnnotation(db.DB, document.ID, c.Param("annotationId"), middleware.GetCurrentUser(c).ID,
+		services.AnnotationUpdate{Comment: req.Comment, Color: req.Color, IsShared: req.IsShared})
+	if err != nil {
+		return annotationHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, annotation)
+}
+
+// DeleteDocumentAnnotationHandler removes the user's own annotation
+func DeleteDocumentAnnotationHandler(c echo.Context) error {
+	document, err := getAnnotatableDocument(c)
+	if err != nil {
+		return err
+	}
+
+	if err := services.DeleteDocumentAnnotation(db.DB, document.ID, c.Param("annotationId"), middleware.GetCurrentUser(c).ID); err != nil {
+		return annotationHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": "Annotation deleted"})
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"html"
 	"law_flow_app_go/db"
 	"law_flow_app_go/middleware"
 	"law_flow_app_go/models"
 	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -17,8 +20,8 @@ func DeleteCaseDocumentHandler(c echo.Context) error {
 	currentUser := middleware.GetCurrentUser(c)
 	currentFirm := middleware.GetCurrentFirm(c)
 
-	// Only admin and lawyer can delete documents
-	if currentUser.Role != "admin" && currentUser.Role != "lawyer" {
+	// Only users who manage cases can delete documents
+	if !currentUser.Can(models.CapabilityCasesManage) {
 		if c.Request().Header.Get("HX-Request") == "true" {
 			return c.HTML(http.StatusForbidden, `<div class="p-4 bg-red-500/20 text-red-400 rounded-lg">Permission denied</div>`)
 		}
@@ -53,6 +56,13 @@ func DeleteCaseDocumentHandler(c echo.Context) error {
 
 	// Perform deletion
 	if err := services.DeleteCaseDocument(db.DB, docID, currentUser.ID, currentFirm.ID); err != nil {
+		if errors.Is(err, services.ErrDocumentUnderLegalHold) {
+			msg := i18n.T(c.Request().Context(), "cases.legal_hold.errors.document_held")
+			if c.Request().Header.Get("HX-Request") == "true" {
+				return c.HTML(http.StatusConflict, `<div class="p-4 bg-red-500/20 text-red-400 rounded-lg">`+html.EscapeString(msg)+`</div>`)
+			}
+			return echo.NewHTTPError(http.StatusConflict, msg)
+		}
 		if c.Request().Header.Get("HX-Request") == "true" {
 			return c.HTML(http.StatusInternalServerError, `<div class="p-4 bg-red-500/20 text-red-400 rounded-lg">Failed to delete document</div>`)
 		}
@@ -153,8 +153,16 @@ func UpdateCaseHandler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Filing number must be less than 24 characters")
 	}
 
+	// Cases awaiting intake approval can only be activated through the approval workflow
+	if caseRecord.IsPendingApproval() && status != models.CaseStatusPendingApproval {
+		if c.Request().Header.Get("HX-Request") == "true" {
+			return c.HTML(http.StatusForbidden, `<div class="p-4 bg-red-500/20 text-red-400 rounded-lg">This case is awaiting intake approval</div>`)
+		}
+		return echo.NewHTTPError(http.StatusForbidden, "This case is awaiting intake approval")
+	}
+
 	// Validate status
-	if !models.IsValidCaseStatus(status) {
+	if !models.IsValidCaseStatus(status) && !caseRecord.IsPendingApproval() {
 		if c.Request().Header.Get("HX-Request") == "true" {
 			return c.HTML(http.StatusBadRequest, `<div class="p-4 bg-red-500/20 text-red-400 rounded-lg">Invalid status</div>`)
 		}
@@ -164,8 +172,8 @@ func UpdateCaseHandler(c echo.Context) error {
 	// Handle Historical Case Logic
 	// 1. If case is Historical and we are trying to change status from CLOSED to something else (Reopening)
 	if caseRecord.IsHistorical && status != models.CaseStatusClosed {
-		// Only Admin and Lawyer can reopen historical cases
-		if currentUser.Role != "admin" && currentUser.Role != "lawyer" {
+		// Only users who manage cases can reopen historical cases
+		if !currentUser.Can(models.CapabilityCasesManage) {
 			if c.Request().Header.Get("HX-Request") == "true" {
 				return c.HTML(http.StatusForbidden, `<div class="p-4 bg-red-500/20 text-red-400 rounded-lg">Permission denied: Only Admins and Lawyers can reopen historical cases</div>`)
 			}
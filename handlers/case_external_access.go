@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+	"law_flow_app_go/config"
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
+	"law_flow_app_go/templates/partials"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetCaseExternalAccessHandler renders the external collaborators tab of a case
+func GetCaseExternalAccessHandler(c echo.Context) error {
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+	return renderCaseExternalAccessPanel(c, caseRecord, "")
+}
+
+// InviteExternalCollaboratorHandler grants an external lawyer time-limited access to a case
+func InviteExternalCollaboratorHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+	ctx := c.Request().Context()
+
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	name := strings.TrimSpace(c.FormValue("name"))
+	email := strings.ToLower(strings.TrimSpace(c.FormValue("email")))
+	if name == "" || email == "" || c.FormValue("expires_at") == "" {
+		return renderCaseExternalAccessPanel(c, caseRecord, i18n.T(ctx, "cases.external.errors.required"))
+	}
+	if len(name) > 255 || len(email) > 320 {
+		return renderCaseExternalAccessPanel(c, caseRecord, i18n.T(ctx, "cases.external.errors.too_long"))
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return renderCaseExternalAccessPanel(c, caseRecord, i18n.T(ctx, "cases.external.errors.invalid_email"))
+	}
+
+	// Access runs until the end of the chosen day in the firm's timezone
+	loc, err := time.LoadLocation(currentFirm.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	expiryDate, err := time.ParseInLocation("2006-01-02", c.FormValue("expires_at"), loc)
+	if err != nil {
+		return renderCaseExternalAccessPanel(c, caseRecord, i18n.T(ctx, "cases.external.errors.invalid_expiry"))
+	}
+	expiresAt := expiryDate.AddDate(0, 0, 1).Add(-time.Second)
+
+	access, created, err := services.InviteExternalCollaborator(db.DB, services.ExternalInvite{
+		FirmID:      currentFirm.ID,
+		CaseID:      caseRecord.ID,
+		InvitedByID: currentUser.ID,
+		Name:        name,
+		Email:       email,
+		ExpiresAt:   expiresAt,
+	}, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrExternalAccessExpiry):
+			return renderCaseExternalAccessPanel(c, caseRecord, i18n.T(ctx, "cases.external.errors.invalid_expiry"))
+		case errors.Is(err, services.ErrExternalEmailInUse):
+			return renderCaseExternalAccessPanel(c, caseRecord, i18n.T(ctx, "cases.external.errors.email_in_use"))
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to invite collaborator")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionCreate,
+		"CaseExternalAccess", access.ID, caseRecord.CaseNumber,
+		"External collaborator invited: "+email, nil,
+		map[string]interface{}{"email": email, "expires_at": expiresAt, "new_account": created})
+
+	sendExternalInviteEmail(c, caseRecord, access, created, expiryDate.Format("02/01/2006"))
+
+	return renderCaseExternalAccessPanel(c, caseRecord, "")
+}
+
+// RevokeExternalAccessHandler ends an external collaborator's access to a case
+func RevokeExternalAccessHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	access, err := services.RevokeExternalAccess(db.DB, currentFirm.ID, caseRecord.ID, c.Param("accessId"), currentUser.ID, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrExternalAccessNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrExternalAccessRevoked):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke access")
+	}
+
+	email := ""
+	if access.User != nil {
+		email = access.User.Email
+	}
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionUpdate,
+		"CaseExternalAccess", access.ID, caseRecord.CaseNumber,
+		"External collaborator access revoked: "+email,
+		map[string]string{"status": models.ExternalAccessStatusActive},
+		map[string]string{"status": models.ExternalAccessStatusRevoked})
+
+	return renderCaseExternalAccessPanel(c, caseRecord, "")
+}
+
+// ToggleDocumentExternalShareHandler shares or unshares a document with the case's external collaborators
+func ToggleDocumentExternalShareHandler(c echo.Context) error {
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	var document models.CaseDocument
+	if err := middleware.GetFirmScopedQuery(c, db.DB).First(&document, "id = ? AND case_id = ?", c.Param("docId"), caseRecord.ID).Error; err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Document not found")
+	}
+
+	document.SharedWithExternal = !document.SharedWithExternal
+	if err := db.DB.Model(&document).Update("shared_with_external", document.SharedWithExternal).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update sharing")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionVisibilityChange,
+		"CaseDocument", document.ID, document.FileOriginalName,
+		"Document external sharing changed",
+		map[string]bool{"shared_with_external": !document.SharedWithExternal},
+		map[string]bool{"shared_with_external": document.SharedWithExternal})
+
+	db.DB.Preload("UploadedBy").First(&document, "id = ?", document.ID)
+	document.UnderLegalHold, _ = services.IsDocumentUnderLegalHold(db.DB, document.ID)
+	component := partials.CaseDocumentRow(c.Request().Context(), document, caseRecord.ID)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
+
+// renderCaseExternalAccessPanel renders the grants and activity of a case's external collaborators
+func renderCaseExternalAccessPanel(c echo.Context, caseRecord *models.Case, errMsg string) error {
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	accesses, err := services.GetCaseExternalAccesses(db.DB, currentFirm.ID, caseRecord.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch external collaborators")
+	}
+	activity, err := services.GetExternalCollaboratorActivity(db.DB, currentFirm.ID, caseRecord.ID, 50)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch external activity")
+	}
+
+	component := partials.CaseExternalAccessPanel(c.Request().Context(), *caseRecord, accesses, activity, time.Now(), errMsg)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
+
+// sendExternalInviteEmail emails the invitee a set-password link (new accounts) or a link to the shared case
+func sendExternalInviteEmail(c echo.Context, caseRecord *models.Case, access *models.CaseExternalAccess, created bool, expiresLabel string) {
+	if access.User == nil {
+		return
+	}
+	cfg := c.Get("config").(*config.Config)
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	link := cfg.AppURL + middleware.ExternalPortalPath + "/cases/" + caseRecord.ID
+	if created {
+		resetToken, err := services.GenerateResetToken(db.DB, access.User.Email)
+		if err != nil || resetToken == nil {
+			c.Logger().Errorf("Failed to create set-password token for external collaborator %s: %v", access.UserID, err)
+			return
+		}
+		link = cfg.AppURL + "/reset-password?token=" + resetToken.Token
+	}
+
+	clientName := ""
+	var client models.User
+	if err := db.DB.Select("name").First(&client, "id = ?", caseRecord.ClientID).Error; err == nil {
+		clientName = client.Name
+	}
+
+	lang := access.User.Language
+	if lang == "" {
+		lang = "es"
+	}
+	email := services.BuildExternalCollaboratorInviteEmail(access.User.Email, services.ExternalCollaboratorInviteEmailData{
+		CollaboratorName: access.User.Name,
+		InviterName:      currentUser.Name,
+		FirmName:         currentFirm.Name,
+		CaseNumber:       caseRecord.CaseNumber,
+		ClientName:       clientName,
+		ExpiresAt:        expiresLabel,
+		NewAccount:       created,
+		Link:             link,
+	}, lang)
+	services.SendEmailAsync(cfg, email)
+}
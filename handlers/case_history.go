@@ -72,7 +72,7 @@ func GetHistoricalCaseFormHandler(c echo.Context) error {
 	documentTypes, _ := services.GetChoiceOptions(db.DB, firm.ID, "document_type")
 
 	// Render the modal
-	component := partials.CaseHistoryModal(c.Request().Context(), clients, lawyers, domains, documentTypes, currentUser)
+	component := partials.CaseHistoryModal(c.Request().Context(), clients, lawyers, domains, documentTypes, currentUser, services.RegistryLookupAvailable(db.DB, firm))
 	return component.Render(c.Request().Context(), c.Response().Writer)
 }
 
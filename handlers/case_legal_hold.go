@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
+	"law_flow_app_go/templates/partials"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetCaseLegalHoldsHandler renders the legal hold tab of a case
+func GetCaseLegalHoldsHandler(c echo.Context) error {
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+	return renderCaseLegalHoldPanel(c, caseRecord, "")
+}
+
+// PlaceLegalHoldHandler places a legal hold on a case or on one of its documents
+func PlaceLegalHoldHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+	ctx := c.Request().Context()
+
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	var documentID *string
+	if docID := strings.TrimSpace(c.FormValue("document_id")); docID != "" {
+		documentID = &docID
+	}
+
+	hold, err := services.PlaceLegalHold(db.DB, services.LegalHoldInput{
+		FirmID:      currentFirm.ID,
+		CaseID:      caseRecord.ID,
+		DocumentID:  documentID,
+		Reason:      c.FormValue("reason"),
+		CustodianID: c.FormValue("custodian_id"),
+		PlacedByID:  currentUser.ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrLegalHoldReasonRequired):
+			return renderCaseLegalHoldPanel(c, caseRecord, i18n.T(ctx, "cases.legal_hold.errors.reason_required"))
+		case errors.Is(err, services.ErrLegalHoldInvalidCustodian):
+			return renderCaseLegalHoldPanel(c, caseRecord, i18n.T(ctx, "cases.legal_hold.errors.invalid_custodian"))
+		case errors.Is(err, services.ErrLegalHoldDuplicate):
+			return renderCaseLegalHoldPanel(c, caseRecord, i18n.T(ctx, "cases.legal_hold.errors.duplicate"))
+		case errors.Is(err, services.ErrLegalHoldDocumentNotFound):
+			return renderCaseLegalHoldPanel(c, caseRecord, i18n.T(ctx, "cases.legal_hold.errors.document_not_found"))
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to place legal hold")
+	}
+
+	scope := "case"
+	if documentID != nil {
+		scope = "document"
+	}
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionCreate,
+		"LegalHold", hold.ID, caseRecord.CaseNumber,
+		"Legal hold placed on "+scope, nil,
+		map[string]interface{}{"document_id": documentID, "reason": hold.Reason, "custodian_id": hold.CustodianID})
+
+	// Document rows show a lock once held
+	c.Response().Header().Set("HX-Trigger", "legalHoldChanged")
+	return renderCaseLegalHoldPanel(c, caseRecord, "")
+}
+
+// ReleaseLegalHoldHandler releases a legal hold (admin only)
+func ReleaseLegalHoldHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+	ctx := c.Request().Context()
+
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	hold, err := services.ReleaseLegalHold(db.DB, currentFirm.ID, c.Param("holdId"), currentUser.ID, c.FormValue("release_reason"), time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrLegalHoldReasonRequired):
+			return renderCaseLegalHoldPanel(c, caseRecord, i18n.T(ctx, "cases.legal_hold.errors.release_reason_required"))
+		case errors.Is(err, services.ErrLegalHoldNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrLegalHoldReleased):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to release legal hold")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionUpdate,
+		"LegalHold", hold.ID, caseRecord.CaseNumber,
+		"Legal hold released",
+		map[string]string{"status": models.LegalHoldStatusActive},
+		map[string]string{"status": models.LegalHoldStatusReleased, "release_reason": *hold.ReleaseReason})
+
+	c.Response().Header().Set("HX-Trigger", "legalHoldChanged")
+	return renderCaseLegalHoldPanel(c, caseRecord, "")
+}
+
+// renderCaseLegalHoldPanel renders a case's holds with the place and release forms
+func renderCaseLegalHoldPanel(c echo.Context, caseRecord *models.Case, errMsg string) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	holds, err := services.GetCaseLegalHolds(db.DB, currentFirm.ID, caseRecord.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch legal holds")
+	}
+
+	var documents []models.CaseDocument
+	if err := db.DB.Select("id", "file_original_name").
+		Where("firm_id = ? AND case_id = ?", currentFirm.ID, caseRecord.ID).
+		Order("file_original_name ASC").
+		Find(&documents).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch documents")
+	}
+
+	var custodians []models.User
+	if err := db.DB.Select("id", "name", "role").
+		Where("firm_id = ? AND is_active = ? AND role IN ?", currentFirm.ID, true, []string{"admin", "lawyer", "staff"}).
+		Order("name ASC").
+		Find(&custodians).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch custodians")
+	}
+
+	component := partials.CaseLegalHoldPanel(c.Request().Context(), *caseRecord, holds, documents, custodians, currentUser.Role == "admin", errMsg)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
@@ -9,6 +9,8 @@ import (
 	"law_flow_app_go/db"
 	"law_flow_app_go/middleware"
 	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
 	"law_flow_app_go/templates/partials"
 
 	"github.com/a-h/templ"
@@ -132,6 +134,7 @@ func CreateCaseLogHandler(c echo.Context) error {
 		Content:     content,
 		CreatedByID: user.ID,
 	}
+	logEntry.SharedWithExternal = c.FormValue("shared_with_external") == "on"
 
 	if documentIDStr != "" {
 		logEntry.DocumentID = &documentIDStr
@@ -254,6 +257,7 @@ func UpdateCaseLogHandler(c echo.Context) error {
 	logEntry.EntryType = entryType
 	logEntry.Title = title
 	logEntry.Content = content
+	logEntry.SharedWithExternal = c.FormValue("shared_with_external") == "on"
 
 	if documentIDStr != "" {
 		logEntry.DocumentID = &documentIDStr
@@ -308,6 +312,15 @@ func DeleteCaseLogHandler(c echo.Context) error {
 		return c.String(http.StatusNotFound, "Log entry not found")
 	}
 
+	// A case-wide legal hold preserves the whole record, log entries included
+	held, err := services.IsCaseUnderLegalHold(db.DB, logEntry.CaseID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Error checking legal hold")
+	}
+	if held {
+		return c.String(http.StatusConflict, i18n.T(c.Request().Context(), "cases.legal_hold.errors.case_held"))
+	}
+
 	if err := db.DB.Delete(&logEntry).Error; err != nil {
 		return c.String(http.StatusInternalServerError, "Error deleting log entry")
 	}
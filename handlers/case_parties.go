@@ -34,7 +34,7 @@ func GetCasePartyModalHandler(c echo.Context) error {
 	}
 
 	// Render the modal
-	component := partials.CasePartyModal(c.Request().Context(), caseRecord, documentTypes, partyType)
+	component := partials.CasePartyModal(c.Request().Context(), caseRecord, documentTypes, partyType, services.RegistryLookupAvailable(db.DB, firm))
 	return component.Render(c.Request().Context(), c.Response().Writer)
 }
 
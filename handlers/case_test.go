@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"law_flow_app_go/models"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -171,6 +173,38 @@ func TestCreateCaseHandler(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
+
+	t.Run("Sole admin skips intake approval", func(t *testing.T) {
+		firm.IntakeApprovalEnabled = true
+		firm.IntakeApprovalBranchIDs = branch.ID
+		defer func() { firm.IntakeApprovalEnabled = false }()
+
+		f := url.Values{}
+		f.Add("client_id", client.ID)
+		f.Add("client_role", "demandante")
+		f.Add("description", "Risky branch case")
+		f.Add("domain_id", domain.ID)
+		f.Add("branch_id", branch.ID)
+		f.Add("assigned_to_id", admin.ID)
+
+		_, c, rec := setupEcho(http.MethodPost, "/api/cases", strings.NewReader(f.Encode()))
+		c.Request().Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		c.Set("user", admin)
+		c.Set("firm", firm)
+
+		err := CreateCaseHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		// Nobody else could approve it, so the case opens instead of waiting forever
+		var created models.Case
+		assert.NoError(t, database.Where("description = ?", "Risky branch case").First(&created).Error)
+		assert.NotEqual(t, models.CaseStatusPendingApproval, created.Status)
+
+		var approvals int64
+		database.Model(&models.CaseApproval{}).Where("case_id = ?", created.ID).Count(&approvals)
+		assert.Equal(t, int64(0), approvals)
+	})
 }
 func TestGetCaseDetailHandler(t *testing.T) {
 	database := setupTestDB(t)
@@ -322,3 +356,60 @@ func TestToggleDocumentVisibilityHandler(t *testing.T) {
 		assert.True(t, updated.IsPublic)
 	})
 }
+
+func TestDocumentAnnotationHandlers(t *testing.T) {
+	database := setupTestDB(t)
+	firm := &models.Firm{ID: "firm-an1", Name: "Annotation Firm"}
+	database.Create(firm)
+	admin := &models.User{ID: "admin-an1", Name: "Admin", Email: "admin-an1@test.com", FirmID: stringToPtr(firm.ID), Role: "admin"}
+	database.Create(admin)
+	lawyer := &models.User{ID: "lawyer-an1", Name: "Lawyer", Email: "lawyer-an1@test.com", FirmID: stringToPtr(firm.ID), Role: "lawyer"}
+	database.Create(lawyer)
+
+	caseRecord := &models.Case{ID: "case-an1", FirmID: firm.ID, CaseNumber: "CASE-AN1", OpenedAt: time.Now(), AssignedToID: &lawyer.ID}
+	database.Create(caseRecord)
+	doc := &models.CaseDocument{ID: "doc-an1", FirmID: firm.ID, CaseID: stringToPtr(caseRecord.ID), FileName: "brief.pdf",
+		FileOriginalName: "brief.pdf", FilePath: "path/to/brief.pdf"}
+	database.Create(doc)
+
+	newContext := func(method, body string, user *models.User) (*httptest.ResponseRecorder, echo.Context) {
+		_, c, rec := setupEcho(method, "/api/cases/case-an1/documents/doc-an1/annotations", strings.NewReader(body))
+		c.Request().Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c.SetParamNames("id", "docId")
+		c.SetParamValues("case-an1", "doc-an1")
+		c.Set("user", user)
+		c.Set("firm", firm)
+		return rec, c
+	}
+
+	t.Run("Create shared comment", func(t *testing.T) {
+		rec, c := newContext(http.MethodPost, `{"type":"comment","page":2,"rects":[{"x":0.4,"y":0.6}],"comment":"Cite the ruling","is_shared":true}`, lawyer)
+		err := CreateDocumentAnnotationHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var created models.DocumentAnnotation
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.Equal(t, lawyer.ID, created.UserID)
+		assert.Equal(t, "Cite the ruling", created.Comment)
+	})
+
+	t.Run("Invalid annotation", func(t *testing.T) {
+		_, c := newContext(http.MethodPost, `{"type":"highlight","page":1}`, lawyer)
+		err := CreateDocumentAnnotationHandler(c)
+		he, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+
+	t.Run("Team sees shared annotations", func(t *testing.T) {
+		rec, c := newContext(http.MethodGet, "", admin)
+		err := GetDocumentAnnotationsHandler(c)
+		assert.NoError(t, err)
+
+		var annotations []models.DocumentAnnotation
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &annotations))
+		assert.Len(t, annotations, 1)
+		assert.Equal(t, "Lawyer", annotations[0].User.Name)
+	})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"archive/zip"
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"law_flow_app_go/db"
@@ -32,6 +33,12 @@ func ComplianceDashboardHandler(c echo.Context) error {
 		Where("firm_id = ? AND status = ?", firm.ID, models.SubjectRequestStatusPending).
 		Count(&pendingRequests)
 
+	// Get active legal holds
+	var activeLegalHolds int64
+	db.DB.Model(&models.LegalHold{}).
+		Where("firm_id = ? AND released_at IS NULL", firm.ID).
+		Count(&activeLegalHolds)
+
 	// Get recent audit logs
 	var recentAuditLogs []models.AuditLog
 	db.DB.Where("firm_id = ?", firm.ID).
@@ -40,11 +47,12 @@ func ComplianceDashboardHandler(c echo.Context) error {
 		Find(&recentAuditLogs)
 
 	data := compliance.DashboardData{
-		User:            user,
-		Firm:            firm,
-		ConsentCount:    consentCount,
-		PendingRequests: pendingRequests,
-		RecentAuditLogs: recentAuditLogs,
+		User:             user,
+		Firm:             firm,
+		ConsentCount:     consentCount,
+		PendingRequests:  pendingRequests,
+		ActiveLegalHolds: activeLegalHolds,
+		RecentAuditLogs:  recentAuditLogs,
 	}
 
 	return render(c, compliance.Dashboard(ctx, data))
@@ -326,3 +334,112 @@ func GetComplianceAuditLogsHandler(c echo.Context) error {
 	}
 	return render(c, compliance.AuditPage(ctx, data))
 }
+
+// GetComplianceLegalHoldsHandler returns paginated legal holds for the compliance report
+func GetComplianceLegalHoldsHandler(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+	firm := c.Get("firm").(*models.Firm)
+	ctx := c.Request().Context()
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit := 20
+	status := c.QueryParam("status")
+
+	holds, total, err := services.GetFirmLegalHolds(db.DB, firm.ID, status, page, limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch legal holds")
+	}
+
+	totalPages := (int(total) + limit - 1) / limit
+
+	// If HTMX request, return just the table fragment
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return render(c, compliance.LegalHoldTable(ctx, holds, page, totalPages, int(total), status))
+	}
+
+	// Otherwise return full page
+	data := compliance.LegalHoldsPageData{
+		User:   user,
+		Firm:   firm,
+		Holds:  holds,
+		Status: status,
+		Page:   page,
+		Total:  int(total),
+		Pages:  totalPages,
+	}
+	return render(c, compliance.LegalHoldsPage(ctx, data))
+}
+
+// ExportComplianceLegalHoldsHandler exports every legal hold of the firm as CSV
+func ExportComplianceLegalHoldsHandler(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+	firm := c.Get("firm").(*models.Firm)
+
+	holds, _, err := services.GetFirmLegalHolds(db.DB, firm.ID, c.QueryParam("status"), 1, -1)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch legal holds")
+	}
+
+	auditLog := models.AuditLog{
+		UserID:       &user.ID,
+		UserName:     user.Name,
+		UserRole:     user.Role,
+		FirmID:       &firm.ID,
+		FirmName:     firm.Name,
+		ResourceType: "legal_hold",
+		ResourceID:   firm.ID,
+		Action:       models.AuditActionDownload,
+		Description:  "Legal hold report exported",
+	}
+	db.DB.Create(&auditLog)
+
+	c.Response().Header().Set("Content-Type", "text/csv")
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=legal_holds_%s.csv", time.Now().Format("2006-01-02")))
+
+	writer := csv.NewWriter(c.Response().Writer)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{
+		"Case Number", "Scope", "Status", "Reason", "Custodian", "Placed By", "Placed At",
+		"Released By", "Released At", "Release Reason",
+	}); err != nil {
+		return err
+	}
+	for _, h := range holds {
+		caseNumber, scope, custodian, placedBy, releasedBy, releasedAt, releaseReason := "", "Entire case", "", "", "", "", ""
+		if h.Case != nil {
+			caseNumber = h.Case.CaseNumber
+		}
+		if !h.IsCaseWide() {
+			scope = "Document"
+			if h.Document != nil {
+				scope = "Document: " + h.Document.FileOriginalName
+			}
+		}
+		if h.Custodian != nil {
+			custodian = h.Custodian.Name
+		}
+		if h.PlacedBy != nil {
+			placedBy = h.PlacedBy.Name
+		}
+		if h.ReleasedBy != nil {
+			releasedBy = h.ReleasedBy.Name
+		}
+		if h.ReleasedAt != nil {
+			releasedAt = h.ReleasedAt.Format("2006-01-02 15:04")
+		}
+		if h.ReleaseReason != nil {
+			releaseReason = *h.ReleaseReason
+		}
+		if err := writer.Write([]string{
+			caseNumber, scope, h.Status(), h.Reason, custodian, placedBy, h.CreatedAt.Format("2006-01-02 15:04"),
+			releasedBy, releasedAt, releaseReason,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
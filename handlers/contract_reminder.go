@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"law_flow_app_go/templates/pages"
+	"law_flow_app_go/templates/partials"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ContractsPageHandler renders the contract renewals page
+func ContractsPageHandler(c echo.Context) error {
+	user := middleware.GetCurrentUser(c)
+	firm := middleware.GetCurrentFirm(c)
+	csrfToken := middleware.GetCSRFToken(c)
+
+	component := pages.Contracts(c.Request().Context(), "Contracts | LexLegal Cloud", csrfToken, user, firm)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
+
+// GetContractRemindersHandler returns the contract reminders visible to the current user
+func GetContractRemindersHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	status := c.QueryParam("status")
+	if status == "" {
+		status = models.ContractStatusActive
+	}
+
+	query := db.DB.Where("firm_id = ?", currentFirm.ID)
+	if status != "all" {
+		query = query.Where("status = ?", status)
+	}
+	query = services.ScopeContractRemindersForUser(query, currentUser)
+
+	var reminders []models.ContractReminder
+	if err := query.Preload("Client").
+		Preload("ResponsibleLawyer").
+		Order("notice_deadline ASC").
+		Find(&reminders).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch contracts")
+	}
+
+	component := partials.ContractReminderTable(c.Request().Context(), reminders, currentUser.Role != "client")
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
+
+// ContractReminderFormModalHandler renders the create/edit modal for a contract reminder
+func ContractReminderFormModalHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	var reminder *models.ContractReminder
+	if id := c.Param("id"); id != "" {
+		existing, err := getScopedContractReminder(c, id)
+		if err != nil {
+			return err
+		}
+		reminder = existing
+	}
+
+	var clients []models.User
+	if err := db.DB.Where("firm_id = ? AND role = ? AND is_active = ?", currentFirm.ID, "client", true).Order("name ASC").Find(&clients).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch clients")
+	}
+
+	var lawyers []models.User
+	if err := db.DB.Where("firm_id = ? AND role IN (?, ?) AND is_active = ?", currentFirm.ID, "lawyer", "admin", true).Order("name ASC").Find(&lawyers).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch lawyers")
+	}
+
+	component := partials.ContractReminderFormModal(c.Request().Context(), currentUser, reminder, clients, lawyers)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
+
+// CreateContractReminderHandler registers a new client contract
+func CreateContractReminderHandler(c echo.Context) error {
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	reminder := models.ContractReminder{
+		FirmID: currentFirm.ID,
+		Status: models.ContractStatusActive,
+	}
+	if err := bindContractReminderForm(c, &reminder); err != nil {
+		return err
+	}
+
+	if err := db.DB.Create(&reminder).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create contract")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionCreate,
+		"ContractReminder", reminder.ID, reminder.Title,
+		"Contract registered", nil, reminder)
+
+	c.Response().Header().Set("HX-Trigger", "reload-contracts")
+	return c.NoContent(http.StatusOK)
+}
+
+// UpdateContractReminderHandler updates a contract's details and recomputes its notice deadline
+func UpdateContractReminderHandler(c echo.Context) error {
+	reminder, err := getScopedContractReminder(c, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	oldReminder := *reminder
+	previousDeadline := reminder.NoticeDeadline
+
+	if err := bindContractReminderForm(c, reminder); err != nil {
+		return err
+	}
+	// Alert again if the deadline moved
+	if !reminder.NoticeDeadline.Equal(previousDeadline) {
+		reminder.AlertSentAt = nil
+	}
+
+	// Clear preloaded associations so Save does not upsert them
+	reminder.Client, reminder.ResponsibleLawyer, reminder.Case = nil, nil, nil
+	if err := db.DB.Save(reminder).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update contract")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionUpdate,
+		"ContractReminder", reminder.ID, reminder.Title,
+		"Contract updated", oldReminder, reminder)
+
+	c.Response().Header().Set("HX-Trigger", "reload-contracts")
+	return c.NoContent(http.StatusOK)
+}
+
+// UpdateContractReminderStatusHandler marks a contract as renewed, terminated or active again
+func UpdateContractReminderStatusHandler(c echo.Context) error {
+	reminder, err := getScopedContractReminder(c, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	status := c.FormValue("status")
+	if status != models.ContractStatusActive && status != models.ContractStatusRenewed && status != models.ContractStatusTerminated {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid status")
+	}
+	oldStatus := reminder.Status
+
+	if err := db.DB.Model(reminder).Update("status", status).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update contract status")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionUpdate,
+		"ContractReminder", reminder.ID, reminder.Title,
+		"Contract status changed", map[string]string{"status": oldStatus}, map[string]string{"status": status})
+
+	c.Response().Header().Set("HX-Trigger", "reload-contracts")
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteContractReminderHandler deletes a contract reminder
+func DeleteContractReminderHandler(c echo.Context) error {
+	reminder, err := getScopedContractReminder(c, c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	if err := db.DB.Delete(reminder).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete contract")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionDelete,
+		"ContractReminder", reminder.ID, reminder.Title,
+		"Contract deleted", reminder, nil)
+
+	c.Response().Header().Set("HX-Trigger", "reload-contracts")
+	return c.NoContent(http.StatusOK)
+}
+
+// getScopedContractReminder loads a contract reminder the current user is allowed to manage
+func getScopedContractReminder(c echo.Context, id string) (*models.ContractReminder, error) {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	reminder, err := services.GetContractReminderByID(db.DB, currentFirm.ID, id)
+	if err != nil {
+		if err == services.ErrContractReminderNotFound {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "Contract not found")
+		}
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve contract")
+	}
+	if currentUser.Role == "lawyer" && reminder.ResponsibleLawyerID != currentUser.ID {
+		return nil, echo.NewHTTPError(http.StatusForbidden, "Access denied")
+	}
+	return reminder, nil
+}
+
+// bindContractReminderForm validates the contract form into the reminder and computes its notice deadline
+func bindContractReminderForm(c echo.Context, reminder *models.ContractReminder) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	title := strings.TrimSpace(c.FormValue("title"))
+	clientID := c.FormValue("client_id")
+	renewalDateStr := c.FormValue("renewal_date")
+	if title == "" || clientID == "" || renewalDateStr == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing required fields")
+	}
+	if len(title) > 255 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Title must be less than 255 characters")
+	}
+
+	renewalDate, err := services.ParseDate(renewalDateStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// Client must belong to the firm
+	var clientCount int64
+	db.DB.Model(&models.User{}).Where("id = ? AND firm_id = ? AND role = ?", clientID, currentFirm.ID, "client").Count(&clientCount)
+	if clientCount == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid client")
+	}
+
+	// Lawyers are always responsible for the contracts they register
+	lawyerID := c.FormValue("responsible_lawyer_id")
+	if currentUser.Role == "lawyer" || lawyerID == "" {
+		lawyerID = currentUser.ID
+	}
+	var lawyerCount int64
+	db.DB.Model(&models.User{}).Where("id = ? AND firm_id = ? AND role IN (?, ?)", lawyerID, currentFirm.ID, "lawyer", "admin").Count(&lawyerCount)
+	if lawyerCount == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid responsible lawyer")
+	}
+
+	noticeDays, _ := strconv.Atoi(c.FormValue("notice_business_days"))
+	alertDays := 30
+	if v, err := strconv.Atoi(c.FormValue("alert_days_before")); err == nil && v >= 0 {
+		alertDays = v
+	}
+	termMonths := 12
+	if v, err := strconv.Atoi(c.FormValue("renewal_term_months")); err == nil && v > 0 {
+		termMonths = v
+	}
+
+	reminder.Title = title
+	reminder.ClientID = clientID
+	reminder.ResponsibleLawyerID = lawyerID
+	reminder.RenewalDate = renewalDate
+	reminder.NoticeBusinessDays = noticeDays
+	reminder.AlertDaysBefore = alertDays
+	reminder.AutoRenews = c.FormValue("auto_renews") == "on"
+	reminder.RenewalTermMonths = termMonths
+	reminder.NotifyClient = c.FormValue("notify_client") == "on"
+
+	reminder.Counterparty = nil
+	if counterparty := strings.TrimSpace(c.FormValue("counterparty")); counterparty != "" {
+		reminder.Counterparty = &counterparty
+	}
+	reminder.Notes = nil
+	if notes := strings.TrimSpace(c.FormValue("notes")); notes != "" {
+		if len(notes) > 5000 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Notes must be less than 5000 characters")
+		}
+		reminder.Notes = &notes
+	}
+
+	if err := services.SetContractNoticeDeadline(db.DB, reminder); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return nil
+}
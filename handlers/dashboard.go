@@ -4,6 +4,7 @@ import (
 	"law_flow_app_go/db"
 	"law_flow_app_go/middleware"
 	"law_flow_app_go/models"
+	"law_flow_app_go/services"
 	"law_flow_app_go/templates/pages"
 	"time"
 
@@ -118,6 +119,15 @@ func DashboardHandler(c echo.Context) error {
 		c.Logger().Error("Failed to fetch upcoming appointments:", err)
 	}
 
+	// 7. Upcoming Contract Renewals (Next 5 notice deadlines)
+	if user.Can(models.CapabilityContractsView) {
+		upcomingRenewals, err := services.GetUpcomingRenewals(db, firm.ID, user, 5)
+		if err != nil {
+			c.Logger().Error("Failed to fetch upcoming renewals:", err)
+		}
+		stats.UpcomingRenewals = upcomingRenewals
+	}
+
 	// Fetch unread notifications
 	var notifications []models.Notification
 	if err := db.Where("firm_id = ? AND (user_id IS NULL OR user_id = ?) AND read_at IS NULL", firm.ID, user.ID).
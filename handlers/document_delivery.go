@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// deliverStoredDocument sends a document for download: a redirect to a signed storage URL,
+// or the file streamed through the app when the firm uses proxy mode or storage is local
+func deliverStoredDocument(c echo.Context, firm *models.Firm, key, fileName string) error {
+	if services.UsesSignedURLs(firm) {
+		signedURL, err := services.Storage.GetSignedURL(context.Background(), key, services.SignedURLExpiration)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate download URL")
+		}
+		return c.Redirect(http.StatusTemporaryRedirect, signedURL)
+	}
+	return streamStoredDocument(c, key, fileName, false)
+}
+
+// streamStoredDocument streams a file from storage, honoring Range requests so embedded
+// viewers can fetch pages on demand and resume interrupted transfers
+func streamStoredDocument(c echo.Context, key, fileName string, inline bool) error {
+	obj, err := services.Storage.GetRange(c.Request().Context(), key, c.Request().Header.Get("Range"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRange) {
+			return echo.NewHTTPError(http.StatusRequestedRangeNotSatisfiable, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve file")
+	}
+	defer obj.Body.Close()
+
+	header := c.Response().Header()
+	header.Set("Content-Type", obj.ContentType)
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("X-Content-Type-Options", "nosniff")
+	if inline {
+		header.Set("Content-Disposition", "inline; filename=\""+fileName+"\"")
+		header.Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	} else {
+		header.Set("Content-Disposition", "attachment; filename=\""+fileName+"\"")
+		header.Set("X-Download-Options", "noopen")
+		header.Set("X-Permitted-Cross-Domain-Policies", "none")
+	}
+
+	// Local files are seekable: the standard library answers range and conditional requests
+	if seeker, ok := obj.Body.(io.ReadSeeker); ok {
+		http.ServeContent(c.Response(), c.Request(), fileName, obj.LastModified, seeker)
+		return nil
+	}
+
+	// R2 already applied the range; relay its partial response
+	if obj.ETag != "" {
+		header.Set("ETag", obj.ETag)
+	}
+	if !obj.LastModified.IsZero() {
+		header.Set("Last-Modified", obj.LastModified.UTC().Format(http.TimeFormat))
+	}
+	header.Set("Content-Length", strconv.FormatInt(obj.ContentLength, 10))
+	status := http.StatusOK
+	if obj.ContentRange != "" {
+		header.Set("Content-Range", obj.ContentRange)
+		status = http.StatusPartialContent
+	}
+	return c.Stream(status, obj.ContentType, obj.Body)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"law_flow_app_go/config"
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/services"
+	"law_flow_app_go/templates/partials"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxWebhookBodySize caps webhook payloads; email events are small JSON documents
+const maxWebhookBodySize = 1 << 20
+
+// ResendWebhookHandler receives delivery, open and click events for logged emails
+func ResendWebhookHandler(c echo.Context) error {
+	cfg := c.Get("config").(*config.Config)
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodySize))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payload")
+	}
+
+	headers := c.Request().Header
+	if err := services.VerifyResendWebhook(cfg.ResendWebhookKey,
+		headers.Get("svix-id"), headers.Get("svix-timestamp"), headers.Get("svix-signature"),
+		body, time.Now()); err != nil {
+		if errors.Is(err, services.ErrWebhookTimestamp) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid signature")
+	}
+
+	event, err := services.ParseResendWebhookEvent(body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid payload")
+	}
+
+	if err := services.ApplyEmailEvent(db.DB, event); err != nil {
+		c.Logger().Errorf("Failed to apply email event %s: %v", event.Type, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to process event")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetCaseCommunicationsHandler lists the notification emails sent to the case's client
+func GetCaseCommunicationsHandler(c echo.Context) error {
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	caseRecord, err := verifyCaseAccess(c, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	logs, err := services.GetClientEmailLogs(db.DB, currentFirm.ID, caseRecord.ClientID, 100)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch communications")
+	}
+
+	component := partials.ClientCommunicationsList(c.Request().Context(), logs, currentFirm.EmailTrackingEnabled)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
+	"law_flow_app_go/templates/pages"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ExternalPortalHandler lists the cases an external collaborator currently has access to
+func ExternalPortalHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	accesses, err := services.GetActiveExternalAccesses(db.DB, currentUser.ID, time.Now())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch shared cases")
+	}
+
+	csrfToken := middleware.GetCSRFToken(c)
+	component := pages.ExternalPortal(c.Request().Context(), "Shared Cases | LexLegal Cloud", csrfToken, currentUser, currentFirm, accesses)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
+
+// ExternalCaseHandler shows the documents and log entries shared on a case
+func ExternalCaseHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	access, err := services.ResolveExternalAccess(db.DB, currentUser.ID, c.Param("id"), time.Now())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	documents, err := services.GetExternalSharedDocuments(db.DB, currentFirm.ID, access.CaseID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch documents")
+	}
+	logs, err := services.GetExternalSharedLogs(db.DB, currentFirm.ID, access.CaseID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch log entries")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionView,
+		"Case", access.CaseID, access.Case.CaseNumber,
+		"Shared case opened by external collaborator", nil,
+		map[string]int{"documents": len(documents), "log_entries": len(logs)})
+
+	csrfToken := middleware.GetCSRFToken(c)
+	component := pages.ExternalCase(c.Request().Context(), access.Case.CaseNumber+" | LexLegal Cloud", csrfToken, currentUser, currentFirm, *access, documents, logs)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
+
+// ExternalDocumentDownloadHandler downloads a document shared with external collaborators
+func ExternalDocumentDownloadHandler(c echo.Context) error {
+	currentUser := middleware.GetCurrentUser(c)
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	access, err := services.ResolveExternalAccess(db.DB, currentUser.ID, c.Param("id"), time.Now())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Case not found")
+	}
+
+	var document models.CaseDocument
+	if err := db.DB.First(&document, "id = ? AND case_id = ? AND firm_id = ? AND shared_with_external = ?",
+		c.Param("docId"), access.CaseID, currentFirm.ID, true).Error; err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Document not found")
+	}
+	if document.FilePath == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "No file attached to this document")
+	}
+	if document.IsFileMissing() && services.FileStillMissing(db.DB, services.Storage, &document, document.FilePath) {
+		return echo.NewHTTPError(http.StatusNotFound, i18n.T(c.Request().Context(), "storage.file_missing_hint"))
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionDownload,
+		"CaseDocument", document.ID, document.FileOriginalName,
+		"Shared document downloaded by external collaborator", nil, nil)
+
+	return deliverStoredDocument(c, currentFirm, document.FilePath, document.FileOriginalName)
+}
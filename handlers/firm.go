@@ -11,6 +11,7 @@ import (
 	"law_flow_app_go/templates/pages"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -207,8 +208,14 @@ func FirmSettingsPageHandler(c echo.Context) error {
 		currencyOptions, _ = services.GetChoiceOptions(db.DB, firm.ID, models.ChoiceCategoryKeyCurrency)
 	}
 
+	// Case branches for the intake approval tab
+	caseBranches, err := services.GetAllCaseBranches(db.DB, firm.ID)
+	if err != nil {
+		c.Logger().Errorf("Failed to fetch case branches: %v", err)
+	}
+
 	// Render the firm settings page
-	component := pages.FirmSettings(c.Request().Context(), "Firm Settings | LexLegal Cloud", csrfToken, user, firm, subscriptionInfo, availableAddOns, currencyOptions, countries)
+	component := pages.FirmSettings(c.Request().Context(), "Firm Settings | LexLegal Cloud", csrfToken, user, firm, subscriptionInfo, availableAddOns, currencyOptions, countries, caseBranches)
 	return component.Render(c.Request().Context(), c.Response().Writer)
 }
 
@@ -250,6 +257,14 @@ func UpdateFirmHandler(c echo.Context) error {
 		"info_email":    firm.InfoEmail,
 		"noreply_email": firm.NoreplyEmail,
 		"currency":      firm.Currency,
+
+		"intake_approval_enabled":          firm.IntakeApprovalEnabled,
+		"intake_approval_amount_threshold": firm.IntakeApprovalAmountThreshold,
+		"intake_approval_branch_ids":       firm.IntakeApprovalBranchIDs,
+		"intake_approval_on_conflict":      firm.IntakeApprovalOnConflict,
+		"email_tracking_enabled":           firm.EmailTrackingEnabled,
+		"registry_lookup_enabled":          firm.RegistryLookupEnabled,
+		"document_proxy_enabled":           firm.DocumentProxyEnabled,
 	}
 
 	// Helper function for HTMX error response
@@ -302,6 +317,8 @@ func UpdateFirmHandler(c echo.Context) error {
 		firm.City = strings.TrimSpace(c.FormValue("city"))
 		firm.Phone = strings.TrimSpace(c.FormValue("phone"))
 		firm.Description = strings.TrimSpace(c.FormValue("description"))
+		firm.RegistryLookupEnabled = c.FormValue("registry_lookup_enabled") == "on"
+		firm.DocumentProxyEnabled = c.FormValue("document_proxy_enabled") == "on"
 
 	} else if updateType == "email" {
 		billingEmail := strings.TrimSpace(c.FormValue("billing_email"))
@@ -314,6 +331,32 @@ func UpdateFirmHandler(c echo.Context) error {
 		firm.InfoEmail = strings.TrimSpace(c.FormValue("info_email"))
 		firm.NoreplyEmail = strings.TrimSpace(c.FormValue("noreply_email"))
 		firm.EmailSenderName = strings.TrimSpace(c.FormValue("email_sender_name"))
+		firm.EmailTrackingEnabled = c.FormValue("email_tracking_enabled") == "on"
+
+	} else if updateType == "intake" {
+		var threshold *float64
+		if thresholdStr := strings.TrimSpace(c.FormValue("intake_approval_amount_threshold")); thresholdStr != "" {
+			value, err := strconv.ParseFloat(thresholdStr, 64)
+			if err != nil || value < 0 {
+				return htmxError("Invalid amount threshold")
+			}
+			threshold = &value
+		}
+
+		// Only keep branches that belong to this firm
+		var branchIDs []string
+		if selected := c.Request().Form["intake_approval_branch_ids"]; len(selected) > 0 {
+			if err := db.DB.Model(&models.CaseBranch{}).
+				Where("firm_id = ? AND id IN ?", firm.ID, selected).
+				Pluck("id", &branchIDs).Error; err != nil {
+				return htmxError("Invalid case branches")
+			}
+		}
+
+		firm.IntakeApprovalEnabled = c.FormValue("intake_approval_enabled") == "on"
+		firm.IntakeApprovalAmountThreshold = threshold
+		firm.IntakeApprovalBranchIDs = strings.Join(branchIDs, ",")
+		firm.IntakeApprovalOnConflict = c.FormValue("intake_approval_on_conflict") == "on"
 
 	} else {
 		// Fallback for legacy requests or unknown types
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
+	"law_flow_app_go/templates/partials"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// firmRoleErrorKeys maps validation errors to the message shown in the roles panel
+var firmRoleErrorKeys = map[error]string{
+	services.ErrFirmRoleNameRequired:      "settings.roles.errors.name_required",
+	services.ErrFirmRoleNameReserved:      "settings.roles.errors.name_reserved",
+	services.ErrFirmRoleDuplicate:         "settings.roles.errors.duplicate",
+	services.ErrFirmRoleInvalidBase:       "settings.roles.errors.invalid_base",
+	services.ErrFirmRoleInvalidCapability: "settings.roles.errors.invalid_capability",
+	services.ErrFirmRoleNoCapabilities:    "settings.roles.errors.no_capabilities",
+	services.ErrFirmRoleInUse:             "settings.roles.errors.in_use",
+}
+
+// firmRoleInput reads a role definition from the submitted form
+func firmRoleInput(c echo.Context) services.FirmRoleInput {
+	input := services.FirmRoleInput{
+		Name:        c.FormValue("name"),
+		Description: c.FormValue("description"),
+		BaseRole:    c.FormValue("base_role"),
+	}
+	input.Capabilities = c.Request().Form["capabilities"]
+	return input
+}
+
+// handleFirmRoleError re-renders the panel with a validation message, or fails the request
+func handleFirmRoleError(c echo.Context, err error) error {
+	for target, key := range firmRoleErrorKeys {
+		if errors.Is(err, target) {
+			return renderFirmRolesPanel(c, i18n.T(c.Request().Context(), key))
+		}
+	}
+	if errors.Is(err, services.ErrFirmRoleNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save role")
+}
+
+// GetFirmRolesTabHandler renders the custom roles tab of the firm settings
+func GetFirmRolesTabHandler(c echo.Context) error {
+	return renderFirmRolesPanel(c, "")
+}
+
+// CreateFirmRoleHandler defines a new custom role
+func CreateFirmRoleHandler(c echo.Context) error {
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	role, err := services.CreateFirmRole(db.DB, currentFirm.ID, firmRoleInput(c))
+	if err != nil {
+		return handleFirmRoleError(c, err)
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionCreate,
+		"FirmRole", role.ID, role.Name, "Custom role created", nil, role)
+
+	return renderFirmRolesPanel(c, "")
+}
+
+// UpdateFirmRoleHandler redefines a custom role
+func UpdateFirmRoleHandler(c echo.Context) error {
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	old, err := services.GetFirmRole(db.DB, currentFirm.ID, c.Param("id"))
+	if err != nil {
+		return handleFirmRoleError(c, err)
+	}
+	oldValues := *old
+
+	role, err := services.UpdateFirmRole(db.DB, currentFirm.ID, old.ID, firmRoleInput(c))
+	if err != nil {
+		return handleFirmRoleError(c, err)
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionUpdate,
+		"FirmRole", role.ID, role.Name, "Custom role updated", oldValues, role)
+
+	return renderFirmRolesPanel(c, "")
+}
+
+// DeleteFirmRoleHandler removes a custom role no user holds
+func DeleteFirmRoleHandler(c echo.Context) error {
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	role, err := services.DeleteFirmRole(db.DB, currentFirm.ID, c.Param("id"))
+	if err != nil {
+		return handleFirmRoleError(c, err)
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	services.LogAuditEvent(db.DB, auditCtx, models.AuditActionDelete,
+		"FirmRole", role.ID, role.Name, "Custom role deleted", role, nil)
+
+	return renderFirmRolesPanel(c, "")
+}
+
+// renderFirmRolesPanel renders the firm's custom roles with the forms to define and edit them
+func renderFirmRolesPanel(c echo.Context, errMsg string) error {
+	currentFirm := middleware.GetCurrentFirm(c)
+
+	roles, err := services.GetFirmRoles(db.DB, currentFirm.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch roles")
+	}
+	userCounts, err := services.CountFirmRoleUsers(db.DB, currentFirm.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch roles")
+	}
+
+	component := partials.FirmRolesPanel(c.Request().Context(), roles, userCounts, errMsg)
+	return component.Render(c.Request().Context(), c.Response().Writer)
+}
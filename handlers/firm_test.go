@@ -193,3 +193,76 @@ func TestCreateFirmHandler(t *testing.T) {
 		assert.Equal(t, http.StatusSeeOther, rec.Code)
 	})
 }
+
+func TestFirmRoleHandlers(t *testing.T) {
+	database := setupTestDB(t)
+	firm := &models.Firm{ID: "firm-roles", Name: "Roles Firm"}
+	database.Create(firm)
+	admin := &models.User{ID: "user-roles-admin", Name: "Roles Admin", Email: "roles-admin@test.com", FirmID: stringToPtr(firm.ID), Role: "admin"}
+	database.Create(admin)
+
+	t.Run("Create role", func(t *testing.T) {
+		f := url.Values{}
+		f.Add("name", "Paralegal")
+		f.Add("base_role", "staff")
+		f.Add("capabilities", models.CapabilityCasesManage)
+		f.Add("capabilities", models.CapabilityCalendarView)
+
+		_, c, rec := setupEcho(http.MethodPost, "/api/firm/roles", strings.NewReader(f.Encode()))
+		c.Request().Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		c.Set("user", admin)
+		c.Set("firm", firm)
+
+		err := CreateFirmRoleHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Paralegal")
+
+		var role models.FirmRole
+		assert.NoError(t, database.Where("firm_id = ? AND name = ?", firm.ID, "Paralegal").First(&role).Error)
+		assert.True(t, role.HasCapability(models.CapabilityCasesView))
+	})
+
+	t.Run("Reserved name re-renders with error", func(t *testing.T) {
+		f := url.Values{}
+		f.Add("name", "Lawyer")
+		f.Add("base_role", "lawyer")
+		f.Add("capabilities", models.CapabilitySearch)
+
+		_, c, rec := setupEcho(http.MethodPost, "/api/firm/roles", strings.NewReader(f.Encode()))
+		c.Request().Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		c.Set("user", admin)
+		c.Set("firm", firm)
+
+		err := CreateFirmRoleHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "alert-error")
+
+		var count int64
+		database.Model(&models.FirmRole{}).Where("firm_id = ?", firm.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Delete role in use is refused", func(t *testing.T) {
+		var role models.FirmRole
+		database.Where("firm_id = ?", firm.ID).First(&role)
+		holder := &models.User{ID: "user-roles-holder", Name: "Holder", Email: "roles-holder@test.com", FirmID: stringToPtr(firm.ID), Role: "staff", CustomRoleID: &role.ID}
+		database.Create(holder)
+
+		_, c, rec := setupEcho(http.MethodDelete, "/api/firm/roles/"+role.ID, nil)
+		c.SetParamNames("id")
+		c.SetParamValues(role.ID)
+		c.Set("user", admin)
+		c.Set("firm", firm)
+
+		err := DeleteFirmRoleHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "alert-error")
+
+		var count int64
+		database.Model(&models.FirmRole{}).Where("id = ?", role.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+}
@@ -118,6 +118,14 @@ func UpdateProfileHandler(c echo.Context) error {
 		user.DocumentNumber = nil
 	}
 
+	// Only firm members get the morning agenda; the field is absent from the form otherwise
+	if user.IsBillable() {
+		agendaDigest := strings.TrimSpace(c.FormValue("agenda_digest"))
+		if models.IsValidAgendaDigest(agendaDigest) {
+			user.AgendaDigest = agendaDigest
+		}
+	}
+
 	// Save changes
 	if err := db.DB.Save(&user).Error; err != nil {
 		if c.Request().Header.Get("HX-Request") == "true" {
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"law_flow_app_go/db"
+	"law_flow_app_go/middleware"
+	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegistryLookupHandler looks up a tax ID in the firm's national public registry so client and
+// opposing party forms can be prefilled. Failures come back as JSON messages the form shows
+// inline; the form keeps working for manual entry.
+func RegistryLookupHandler(c echo.Context) error {
+	currentFirm := middleware.GetCurrentFirm(c)
+	ctx := c.Request().Context()
+
+	result, err := services.LookupRegistry(db.DB, currentFirm, c.QueryParam("tax_id"), time.Now())
+	if err != nil {
+		// Plain JSON rather than echo.HTTPError: the error handler would render 403/404 as HTML pages
+		code, key := http.StatusServiceUnavailable, "cases.registry.unavailable"
+		switch {
+		case errors.Is(err, services.ErrRegistryLookupDisabled), errors.Is(err, services.ErrRegistryLookupUnsupported):
+			code, key = http.StatusForbidden, "cases.registry.disabled"
+		case errors.Is(err, services.ErrRegistryInvalidTaxID):
+			code, key = http.StatusBadRequest, "cases.registry.invalid"
+		case errors.Is(err, services.ErrRegistryNotFound):
+			code, key = http.StatusNotFound, "cases.registry.not_found"
+		}
+		return c.JSON(code, map[string]string{"message": i18n.T(ctx, key)})
+	}
+
+	var message string
+	switch {
+	case result.Stale:
+		message = i18n.T(ctx, "cases.registry.stale", map[string]interface{}{
+			"source": result.Record.Source,
+			"date":   result.FetchedAt.Format("02/01/2006"),
+		})
+	case result.Record.Status != "":
+		message = i18n.T(ctx, "cases.registry.found_status", map[string]interface{}{
+			"source": result.Record.Source,
+			"status": result.Record.Status,
+		})
+	default:
+		message = i18n.T(ctx, "cases.registry.found", map[string]interface{}{"source": result.Record.Source})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"record":     result.Record,
+		"stale":      result.Stale,
+		"fetched_at": result.FetchedAt,
+		"message":    message,
+	})
+}
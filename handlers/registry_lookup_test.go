@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/registry"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// emptyRegistry is a registry.Provider that knows no tax ID
+type emptyRegistry struct{}
+
+func (emptyRegistry) Name() string { return "EMPTY" }
+
+func (emptyRegistry) LookupByTaxID(taxID string) (*registry.Record, error) {
+	return nil, registry.ErrNotFound
+}
+
+func TestRegistryLookupHandler(t *testing.T) {
+	database := setupTestDB(t)
+	country := &models.Country{ID: "country-registry", Name: "Registryland", Code: "ZZH"}
+	database.Create(country)
+	firm := &models.Firm{ID: "firm-registry", Name: "Registry Firm", CountryID: country.ID, RegistryLookupEnabled: true}
+	database.Create(firm)
+	user := &models.User{ID: "user-registry", Name: "Registry User", Email: "registry@test.com", FirmID: stringToPtr(firm.ID), Role: "lawyer"}
+	database.Create(user)
+
+	registry.RegisterProvider("ZZH", emptyRegistry{})
+	t.Cleanup(func() { registry.RegisterProvider("ZZH", nil) })
+
+	// Failures are JSON so the form can show the message, not the HTML error page
+	t.Run("Not found", func(t *testing.T) {
+		_, c, rec := setupEcho(http.MethodGet, "/api/registry/lookup?tax_id=900123456", nil)
+		c.Set("user", user)
+		c.Set("firm", firm)
+
+		err := RegistryLookupHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.NotEmpty(t, body["message"])
+	})
+
+	t.Run("New client form offers prefill", func(t *testing.T) {
+		_, c, rec := setupEcho(http.MethodGet, "/users/new", nil)
+		c.Set("user", user)
+		c.Set("firm", firm)
+
+		err := GetUserFormNew(c)
+		assert.NoError(t, err)
+		assert.Contains(t, rec.Body.String(), "registryLookup(")
+		assert.Contains(t, rec.Body.String(), `name="document_number"`)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		disabled := *firm
+		disabled.RegistryLookupEnabled = false
+
+		_, c, rec := setupEcho(http.MethodGet, "/api/registry/lookup?tax_id=900123456", nil)
+		c.Set("user", user)
+		c.Set("firm", &disabled)
+
+		err := RegistryLookupHandler(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	})
+}
@@ -6,11 +6,11 @@ import (
 	"law_flow_app_go/middleware"
 	"law_flow_app_go/models"
 	"law_flow_app_go/services"
+	"law_flow_app_go/services/i18n"
 	"law_flow_app_go/templates/partials"
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -162,8 +162,9 @@ func UploadServiceDocumentHandler(c echo.Context) error {
 	return GetServiceDocumentsHandler(c)
 }
 
-// DownloadServiceDocumentHandler serves the file
-func DownloadServiceDocumentHandler(c echo.Context) error {
+// getServiceDocumentFile fetches a service document the current user may access, ensuring its
+// file can be served
+func getServiceDocumentFile(c echo.Context) (*models.ServiceDocument, error) {
 	serviceID := c.Param("id")
 	docID := c.Param("did")
 	currentUser := middleware.GetCurrentUser(c)
@@ -171,23 +172,25 @@ func DownloadServiceDocumentHandler(c echo.Context) error {
 
 	var doc models.ServiceDocument
 	if err := db.DB.Where("firm_id = ? AND id = ? AND service_id = ?", currentFirm.ID, docID, serviceID).First(&doc).Error; err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Document not found")
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Document not found")
 	}
 
-	// Client access check
+	// Client access check: clients only see documents made visible to them
 	if currentUser.Role == "client" && !doc.IsPublic {
-		// Also verify client owns the service
-		var service models.LegalService
-		db.DB.Select("client_id").First(&service, "id = ?", serviceID)
-		if service.ClientID != currentUser.ID {
-			return echo.NewHTTPError(http.StatusForbidden, "Access denied")
-		}
-		if !doc.IsPublic {
-			// Actually clients should generally see docs they uploaded?
-			// but if lawyer uploaded as private, client cant see.
-			// assuming 'IsPublic' implies 'Visible to Client'.
-			return echo.NewHTTPError(http.StatusForbidden, "Document is private")
-		}
+		return nil, echo.NewHTTPError(http.StatusForbidden, "Access denied")
+	}
+
+	if doc.IsFileMissing() && services.FileStillMissing(db.DB, services.Storage, &doc, doc.FilePath) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, i18n.T(c.Request().Context(), "storage.file_missing_hint"))
+	}
+	return &doc, nil
+}
+
+// DownloadServiceDocumentHandler serves the file
+func DownloadServiceDocumentHandler(c echo.Context) error {
+	doc, err := getServiceDocumentFile(c)
+	if err != nil {
+		return err
 	}
 
 	// Audit download
@@ -196,28 +199,7 @@ func DownloadServiceDocumentHandler(c echo.Context) error {
 		"ServiceDocument", doc.ID, doc.FileOriginalName,
 		"Document downloaded", nil, nil)
 
-	// Handle R2 signed url vs local
-	if _, ok := services.Storage.(*services.R2Storage); ok {
-		url, err := services.Storage.GetSignedURL(context.Background(), doc.FilePath, 15*time.Minute)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get download URL")
-		}
-		return c.Redirect(http.StatusTemporaryRedirect, url)
-	}
-
-	// Local
-	reader, contentType, err := services.Storage.Get(context.Background(), doc.FilePath)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to read file")
-	}
-	defer reader.Close()
-
-	c.Response().Header().Set("Content-Type", contentType)
-	c.Response().Header().Set("Content-Disposition", "attachment; filename=\""+doc.FileOriginalName+"\"")
-	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
-	c.Response().Header().Set("X-Download-Options", "noopen")
-	c.Response().Header().Set("X-Permitted-Cross-Domain-Policies", "none")
-	return c.Stream(http.StatusOK, contentType, reader)
+	return deliverStoredDocument(c, middleware.GetCurrentFirm(c), doc.FilePath, doc.FileOriginalName)
 }
 
 // DeleteServiceDocumentHandler deletes a document
@@ -277,25 +259,11 @@ func ToggleServiceDocumentVisibilityHandler(c echo.Context) error {
 	return component.Render(c.Request().Context(), c.Response().Writer)
 }
 
-// ViewServiceDocumentHandler serves a PDF document for inline viewing
+// ViewServiceDocumentHandler streams a PDF document for inline viewing
 func ViewServiceDocumentHandler(c echo.Context) error {
-	serviceID := c.Param("id")
-	docID := c.Param("did")
-	currentUser := middleware.GetCurrentUser(c)
-	currentFirm := middleware.GetCurrentFirm(c)
-
-	var doc models.ServiceDocument
-	if err := db.DB.Where("firm_id = ? AND id = ? AND service_id = ?", currentFirm.ID, docID, serviceID).First(&doc).Error; err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Document not found")
-	}
-
-	// Client access check
-	if currentUser.Role == "client" && !doc.IsPublic {
-		var service models.LegalService
-		db.DB.Select("client_id").First(&service, "id = ?", serviceID)
-		if service.ClientID != currentUser.ID || !doc.IsPublic {
-			return echo.NewHTTPError(http.StatusForbidden, "Access denied")
-		}
+	doc, err := getServiceDocumentFile(c)
+	if err != nil {
+		return err
 	}
 
 	// Validate it's a PDF
@@ -309,17 +277,5 @@ func ViewServiceDocumentHandler(c echo.Context) error {
 		"ServiceDocument", doc.ID, doc.FileOriginalName,
 		"Document viewed inline", nil, nil)
 
-	// Get from storage (works for both R2 and local)
-	reader, contentType, err := services.Storage.Get(context.Background(), doc.FilePath)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve file")
-	}
-	defer reader.Close()
-
-	c.Response().Header().Set("Content-Type", contentType)
-	c.Response().Header().Set("Content-Disposition", "inline; filename=\""+doc.FileOriginalName+"\"")
-	c.Response().Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
-
-	// Stream the file to the response
-	return c.Stream(http.StatusOK, contentType, reader)
+	return streamStoredDocument(c, doc.FilePath, doc.FileOriginalName, true)
 }
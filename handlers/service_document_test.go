@@ -2,13 +2,16 @@ package handlers
 
 import (
 	"context"
+	"law_flow_app_go/config"
 	"law_flow_app_go/models"
 	"law_flow_app_go/services"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -228,3 +231,130 @@ func TestViewServiceDocumentHandler(t *testing.T) {
 		assert.Contains(t, rec.Header().Get("Content-Disposition"), "inline")
 	})
 }
+
+func TestViewServiceDocumentHandlerRange(t *testing.T) {
+	database := setupTestDB(t)
+	firm := &models.Firm{ID: "firm-sdc6", Name: "Range Firm"}
+	database.Create(firm)
+	admin := &models.User{ID: "admin-sdc6", Name: "Admin", Email: "admin-sdc6@test.com", FirmID: stringToPtr(firm.ID), Role: "admin"}
+	database.Create(admin)
+
+	doc := &models.ServiceDocument{
+		ID:               "doc-range",
+		FirmID:           firm.ID,
+		ServiceID:        "service-1",
+		FileOriginalName: "range.pdf",
+		FilePath:         "firms/firm-sdc6/services/service-1/range.pdf",
+	}
+	database.Create(doc)
+
+	content := "0123456789"
+	_, _ = services.Storage.UploadReader(context.Background(), strings.NewReader(content), doc.FilePath, "application/pdf", int64(len(content)))
+
+	_, c, rec := setupEcho(http.MethodGet, "/api/services/service-1/documents/doc-range/view", nil)
+	c.Request().Header.Set("Range", "bytes=2-5")
+	c.SetParamNames("id", "did")
+	c.SetParamValues("service-1", "doc-range")
+	c.Set("user", admin)
+	c.Set("firm", firm)
+
+	err := ViewServiceDocumentHandler(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.Equal(t, "2345", rec.Body.String())
+	assert.Equal(t, "bytes 2-5/10", rec.Header().Get("Content-Range"))
+	assert.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+}
+
+func TestServiceDocumentSignedDownload(t *testing.T) {
+	database := setupTestDB(t)
+	firm := &models.Firm{ID: "firm-sdc7", Name: "Signed Firm"}
+	database.Create(firm)
+	admin := &models.User{ID: "admin-sdc7", Name: "Admin", Email: "admin-sdc7@test.com", FirmID: stringToPtr(firm.ID), Role: "admin"}
+	database.Create(admin)
+
+	doc := &models.ServiceDocument{
+		ID:               "doc-signed",
+		FirmID:           firm.ID,
+		ServiceID:        "service-1",
+		FileOriginalName: "signed.pdf",
+		FilePath:         "firms/firm-sdc7/services/service-1/signed.pdf",
+	}
+	database.Create(doc)
+
+	r2, err := services.NewR2Storage(&config.Config{
+		R2AccountID:       "test-account",
+		R2AccessKeyID:     "test-key",
+		R2SecretAccessKey: "test-secret",
+		R2BucketName:      "test-bucket",
+	})
+	assert.NoError(t, err)
+	previous := services.Storage
+	services.Storage = r2
+	defer func() { services.Storage = previous }()
+
+	// Every click on the download link is signed anew, so a tab left open never serves a stale URL
+	_, c, rec := setupEcho(http.MethodGet, "/api/services/service-1/documents/doc-signed/download", nil)
+	c.SetParamNames("id", "did")
+	c.SetParamValues("service-1", "doc-signed")
+	c.Set("user", admin)
+	c.Set("firm", firm)
+
+	err = DownloadServiceDocumentHandler(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTemporaryRedirect, rec.Code)
+	assert.Contains(t, rec.Header().Get("Location"), "test-account.r2.cloudflarestorage.com")
+	assert.Contains(t, rec.Header().Get("Location"), "X-Amz-Expires=900")
+}
+
+func TestServiceDocumentFlaggedMissing(t *testing.T) {
+	database := setupTestDB(t)
+	firm := &models.Firm{ID: "firm-sdc8", Name: "Missing Firm"}
+	database.Create(firm)
+	admin := &models.User{ID: "admin-sdc8", Name: "Admin", Email: "admin-sdc8@test.com", FirmID: stringToPtr(firm.ID), Role: "admin"}
+	database.Create(admin)
+
+	missingAt := time.Now().Add(-time.Hour)
+	doc := &models.ServiceDocument{
+		ID:               "doc-flagged",
+		FirmID:           firm.ID,
+		ServiceID:        "service-1",
+		FileOriginalName: "flagged.pdf",
+		FilePath:         "firms/firm-sdc8/services/service-1/flagged.pdf",
+		FileMissingAt:    &missingAt,
+	}
+	database.Create(doc)
+	_ = services.Storage.Delete(context.Background(), doc.FilePath)
+	t.Cleanup(func() { _ = services.Storage.Delete(context.Background(), doc.FilePath) })
+
+	view := func() (*httptest.ResponseRecorder, error) {
+		_, c, rec := setupEcho(http.MethodGet, "/api/services/service-1/documents/doc-flagged/view", nil)
+		c.SetParamNames("id", "did")
+		c.SetParamValues("service-1", "doc-flagged")
+		c.Set("user", admin)
+		c.Set("firm", firm)
+		return rec, ViewServiceDocumentHandler(c)
+	}
+
+	t.Run("Still missing", func(t *testing.T) {
+		_, err := view()
+		httpErr, ok := err.(*echo.HTTPError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	})
+
+	// A restored file is served at once and the flag cleared, without waiting for the nightly check
+	t.Run("Restored", func(t *testing.T) {
+		content := "restored"
+		_, _ = services.Storage.UploadReader(context.Background(), strings.NewReader(content), doc.FilePath, "application/pdf", int64(len(content)))
+
+		rec, err := view()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, content, rec.Body.String())
+
+		var reloaded models.ServiceDocument
+		database.First(&reloaded, "id = ?", doc.ID)
+		assert.Nil(t, reloaded.FileMissingAt)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"law_flow_app_go/services"
 	"law_flow_app_go/templates/superadmin"
 	superadmin_partials "law_flow_app_go/templates/superadmin/partials"
+	"log"
 	"net/http"
 	"strings"
 
@@ -284,6 +285,19 @@ func SuperadminToggleUserActive(c echo.Context) error {
 	user.IsActive = !user.IsActive
 	db.DB.Save(&user)
 
+	// Keep seat accounting in sync (superadmins may reactivate beyond the plan limit)
+	if user.FirmID != nil {
+		var seatErr error
+		if user.IsActive {
+			seatErr = services.ReactivateUserSeat(db.DB, *user.FirmID, &user)
+		} else {
+			seatErr = services.DeactivateUserSeat(db.DB, *user.FirmID, &user)
+		}
+		if seatErr != nil {
+			log.Printf("Error updating seat usage for user %s: %v", user.ID, seatErr)
+		}
+	}
+
 	return SuperadminGetUsersListHTMX(c)
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"law_flow_app_go/db"
 	"law_flow_app_go/middleware"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/microcosm-cc/bluemonday"
+	"gorm.io/gorm"
 )
 
 // TemplatesPageHandler renders the templates management page
@@ -530,3 +532,83 @@ func DeleteCategoryHandler(c echo.Context) error {
 	// Return updated list
 	return GetCategoriesHandler(c)
 }
+
+// GetTemplatePreviewPanelHandler renders the side-by-side preview panel for the template editor
+func GetTemplatePreviewPanelHandler(c echo.Context) error {
+	id := c.Param("id")
+	firm := c.Get("firm").(*models.Firm)
+
+	var template models.DocumentTemplate
+	if err := middleware.GetFirmScopedQuery(c, db.DB).First(&template, "id = ?", id).Error; err != nil {
+		return c.String(http.StatusNotFound, "Template not found")
+	}
+
+	// Recent cases the author can use as sample data
+	var cases []models.Case
+	if err := templatePreviewCaseQuery(c).Preload("Client").Order("opened_at DESC").Limit(50).Find(&cases).Error; err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to fetch cases")
+	}
+
+	countryName := ""
+	if firm.Country != nil {
+		countryName = firm.Country.Name
+	}
+
+	return partials.TemplatePreviewPanel(c.Request().Context(), template, cases, countryName).Render(c.Request().Context(), c.Response().Writer)
+}
+
+// PreviewTemplateContentHandler renders unsaved editor content against a sample case or synthetic data
+func PreviewTemplateContentHandler(c echo.Context) error {
+	id := c.Param("id")
+	firm := c.Get("firm").(*models.Firm)
+
+	var template models.DocumentTemplate
+	if err := middleware.GetFirmScopedQuery(c, db.DB).First(&template, "id = ?", id).Error; err != nil {
+		return c.String(http.StatusNotFound, "Template not found")
+	}
+
+	// Preview the editor content when provided, otherwise the saved version
+	content := c.FormValue("content")
+	if content == "" {
+		content = template.Content
+	}
+	if len(content) > 500000 {
+		return c.String(http.StatusBadRequest, "Content is too large (max 500KB)")
+	}
+	p := bluemonday.UGCPolicy()
+	content = p.Sanitize(content)
+
+	var data services.TemplateData
+	if caseID := c.FormValue("case_id"); caseID != "" {
+		var caseRecord models.Case
+		if err := templatePreviewCaseQuery(c).
+			Preload("Client").
+			Preload("Client.DocumentType").
+			Preload("AssignedTo").
+			Preload("Domain").
+			Preload("Branch").
+			Preload("Subtypes").
+			First(&caseRecord, "id = ?", caseID).Error; err != nil {
+			return c.String(http.StatusNotFound, "Case not found")
+		}
+		data = services.BuildTemplateDataFromCase(&caseRecord, firm)
+	} else {
+		data = services.BuildSampleTemplateData(firm, time.Now())
+	}
+
+	renderedContent := services.RenderTemplate(content, data)
+	return partials.TemplatePreview(c.Request().Context(), renderedContent).Render(c.Request().Context(), c.Response().Writer)
+}
+
+// templatePreviewCaseQuery scopes the cases usable as preview data; lawyers only see their own cases
+func templatePreviewCaseQuery(c echo.Context) *gorm.DB {
+	user := c.Get("user").(*models.User)
+	query := middleware.GetFirmScopedQuery(c, db.DB).Model(&models.Case{})
+	if user.Role == "lawyer" {
+		query = query.Where(
+			db.DB.Where("assigned_to_id = ?", user.ID).
+				Or("EXISTS (SELECT 1 FROM case_collaborators WHERE case_collaborators.case_id = cases.id AND case_collaborators.user_id = ?)", user.ID),
+		)
+	}
+	return query
+}
@@ -58,6 +58,14 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		&models.CaseMilestone{},
 		&models.Availability{},
 		&models.BlockedDate{},
+		&models.ContractReminder{},
+		&models.CaseApproval{},
+		&models.EmailLog{},
+		&models.CaseExternalAccess{},
+		&models.RegistryLookup{},
+		&models.LegalHold{},
+		&models.DocumentAnnotation{},
+		&models.FirmRole{},
 	)
 	assert.NoError(t, err)
 
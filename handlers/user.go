@@ -12,7 +12,9 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/a-h/templ"
 	"github.com/labstack/echo/v4"
 )
 
@@ -64,6 +66,11 @@ func CreateUser(c echo.Context) error {
 	if role == "" {
 		role = "staff" // Default to staff
 	}
+	// Custom roles are stored as their base role
+	role, customRoleID, err := services.ResolveRoleSelection(db.DB, firm.ID, role)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role"})
+	}
 
 	// Only check user limits for billable roles (admin, lawyer, staff)
 	// Clients don't count towards the user limit
@@ -193,7 +200,8 @@ func CreateUser(c echo.Context) error {
 	user.Name = c.FormValue("name")
 	user.Email = strings.ToLower(strings.TrimSpace(c.FormValue("email")))
 	user.Password = c.FormValue("password")
-	user.Role = c.FormValue("role")
+	user.Role = role
+	user.CustomRoleID = customRoleID
 
 	// Handle optional fields
 	if address := c.FormValue("address"); address != "" {
@@ -222,7 +230,7 @@ func CreateUser(c echo.Context) error {
 	}
 	if len(user.Password) > 72 {
 		if c.Request().Header.Get("HX-Request") == "true" {
-			return partials.UserFormModal(c.Request().Context(), user, false, "Password must be less than 72 characters").Render(c.Request().Context(), c.Response().Writer)
+			return userFormModal(c, user, false, "Password must be less than 72 characters").Render(c.Request().Context(), c.Response().Writer)
 		}
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Password must be less than 72 characters"})
 	}
@@ -231,7 +239,7 @@ func CreateUser(c echo.Context) error {
 	}
 	if user.PhoneNumber != nil && len(*user.PhoneNumber) > 20 {
 		if c.Request().Header.Get("HX-Request") == "true" {
-			return partials.UserFormModal(c.Request().Context(), user, false, "Phone number must be less than 20 characters").Render(c.Request().Context(), c.Response().Writer)
+			return userFormModal(c, user, false, "Phone number must be less than 20 characters").Render(c.Request().Context(), c.Response().Writer)
 		}
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Phone number must be less than 20 characters"})
 	}
@@ -242,7 +250,7 @@ func CreateUser(c echo.Context) error {
 	// Validate required fields
 	if user.Email == "" || user.Password == "" || user.Name == "" {
 		if c.Request().Header.Get("HX-Request") == "true" {
-			return partials.UserFormModal(c.Request().Context(), user, false, "Name, email, and password are required").Render(c.Request().Context(), c.Response().Writer)
+			return userFormModal(c, user, false, "Name, email, and password are required").Render(c.Request().Context(), c.Response().Writer)
 		}
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "Name, email, and password are required",
@@ -265,7 +273,7 @@ func CreateUser(c echo.Context) error {
 	// Validate password strength
 	if err := services.ValidatePassword(user.Password); err != nil {
 		if c.Request().Header.Get("HX-Request") == "true" {
-			return partials.UserFormModal(c.Request().Context(), user, false, err.Error()).Render(c.Request().Context(), c.Response().Writer)
+			return userFormModal(c, user, false, err.Error()).Render(c.Request().Context(), c.Response().Writer)
 		}
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": err.Error(),
@@ -276,7 +284,7 @@ func CreateUser(c echo.Context) error {
 	hashedPassword, err := services.HashPassword(user.Password)
 	if err != nil {
 		if c.Request().Header.Get("HX-Request") == "true" {
-			return partials.UserFormModal(c.Request().Context(), user, false, "Failed to hash password").Render(c.Request().Context(), c.Response().Writer)
+			return userFormModal(c, user, false, "Failed to hash password").Render(c.Request().Context(), c.Response().Writer)
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to hash password",
@@ -369,7 +377,9 @@ func UpdateUser(c echo.Context) error {
 	// Store original values that shouldn't be changed by non-admins
 	originalFirmID := user.FirmID
 	originalRole := user.Role
+	originalCustomRoleID := user.CustomRoleID
 	originalPassword := user.Password
+	wasActive := user.IsActive
 	currentUser := middleware.GetCurrentUser(c)
 	firm := middleware.GetCurrentFirm(c)
 
@@ -378,6 +388,7 @@ func UpdateUser(c echo.Context) error {
 		"name":             user.Name,
 		"email":            user.Email,
 		"role":             user.Role,
+		"custom_role_id":   user.CustomRoleID,
 		"is_active":        user.IsActive,
 		"address":          user.Address,
 		"phone_number":     user.PhoneNumber,
@@ -399,7 +410,13 @@ func UpdateUser(c echo.Context) error {
 		user.Email = email
 	}
 	if role != "" {
-		user.Role = role
+		// Custom roles are stored as their base role
+		resolvedRole, customRoleID, err := services.ResolveRoleSelection(db.DB, firm.ID, role)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid role"})
+		}
+		user.Role = resolvedRole
+		user.CustomRoleID = customRoleID
 	}
 	user.IsActive = isActiveStr == "true"
 
@@ -438,6 +455,7 @@ func UpdateUser(c echo.Context) error {
 	if currentUser.Role != "admin" {
 		user.FirmID = originalFirmID
 		user.Role = originalRole
+		user.CustomRoleID = originalCustomRoleID
 	}
 
 	// Validate role if admin is changing it
@@ -456,12 +474,33 @@ func UpdateUser(c echo.Context) error {
 	// (should have separate password change endpoint)
 	user.Password = originalPassword
 
+	// Reactivating a user whose seat was already released needs a free seat
+	if !wasActive && user.IsActive {
+		if limitResult, err := services.CanReactivateUser(db.DB, firm.ID, &user); err != nil {
+			return userSeatLimitError(c, limitResult, err)
+		}
+	}
+
 	if err := db.DB.Save(&user).Error; err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to update user",
 		})
 	}
 
+	// Seat billing: deactivation frees the seat after the current period, reactivation claims it back
+	if wasActive != user.IsActive {
+		var seatErr error
+		if user.IsActive {
+			seatErr = services.ReactivateUserSeat(db.DB, firm.ID, &user)
+		} else {
+			seatErr = services.DeactivateUserSeat(db.DB, firm.ID, &user)
+		}
+		if seatErr != nil {
+			// Log but don't fail - usage will be recalculated on next check
+			services.LogSecurityEvent(db.DB, "USAGE_UPDATE_FAILED", currentUser.ID, "Failed to update seat usage: "+seatErr.Error())
+		}
+	}
+
 	// Log security event if admin modified another user
 	if currentUser.ID != user.ID {
 		services.LogSecurityEvent(db.DB, "USER_MODIFIED", currentUser.ID, "Modified user: "+user.ID)
@@ -521,8 +560,8 @@ func DeleteUser(c echo.Context) error {
 		})
 	}
 
-	// Update usage cache for billable users
-	if user.Role == "admin" || user.Role == "lawyer" || user.Role == "staff" {
+	// Update usage cache if the user was still holding a seat
+	if user.HoldsSeat(time.Now()) {
 		if err := services.UpdateFirmUsageAfterUserChange(db.DB, firm.ID, -1); err != nil {
 			// Log but don't fail - usage will be recalculated on next check
 			services.LogSecurityEvent(db.DB, "USAGE_UPDATE_FAILED", currentUser.ID, "Failed to update user count: "+err.Error())
@@ -551,6 +590,45 @@ func DeleteUser(c echo.Context) error {
 	return c.JSON(http.StatusNoContent, nil)
 }
 
+// userSeatLimitError renders the response for a user update blocked by the seat limit
+func userSeatLimitError(c echo.Context, limitResult *services.LimitCheckResult, err error) error {
+	ctx := c.Request().Context()
+	if err != services.ErrUserLimitReached && err != services.ErrSubscriptionExpired {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check subscription limits")
+	}
+
+	titleKey := "subscription.errors.user_limit_title"
+	key := "subscription.errors.user_limit_reached"
+	btnKey := "subscription.errors.upgrade_plan"
+	if err == services.ErrSubscriptionExpired {
+		titleKey = "subscription.errors.subscription_expired_title"
+		key = "subscription.errors.subscription_expired"
+		btnKey = "subscription.errors.renew_now"
+	}
+	var args map[string]interface{}
+	if limitResult != nil && limitResult.TranslationKey != "" {
+		key = limitResult.TranslationKey
+		args = limitResult.TranslationArgs
+	}
+	message := i18n.T(ctx, key, args)
+
+	if c.Request().Header.Get("HX-Request") == "true" {
+		return c.HTML(http.StatusForbidden, `
+			<div class="alert alert-warning shadow-lg">
+				<svg xmlns="http://www.w3.org/2000/svg" class="stroke-current shrink-0 h-6 w-6" fill="none" viewBox="0 0 24 24">
+					<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M12 9v2m0 4h.01m-6.938 4h13.856c1.54 0 2.502-1.667 1.732-3L13.732 4c-.77-1.333-2.694-1.333-3.464 0L3.34 16c-.77 1.333.192 3 1.732 3z"/>
+				</svg>
+				<div>
+					<h3 class="font-bold">`+i18n.T(ctx, titleKey)+`</h3>
+					<div class="text-xs">`+message+`</div>
+				</div>
+				<a href="/firm/settings#subscription" class="btn btn-sm btn-primary">`+i18n.T(ctx, btnKey)+`</a>
+			</div>
+		`)
+	}
+	return echo.NewHTTPError(http.StatusForbidden, message)
+}
+
 // UsersPageHandler renders the users management page
 func UsersPageHandler(c echo.Context) error {
 	currentUser := middleware.GetCurrentUser(c)
@@ -613,7 +691,7 @@ func GetUsersListHTMX(c echo.Context) error {
 
 	// Fetch paginated users
 	var users []models.User
-	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+	if err := query.Preload("CustomRole").Order("created_at DESC").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to fetch users",
 		})
@@ -624,10 +702,22 @@ func GetUsersListHTMX(c echo.Context) error {
 	return component.Render(c.Request().Context(), c.Response().Writer)
 }
 
+// userFormModal renders the user form with the firm's custom roles among the role options,
+// and registry prefill for clients when the firm has it
+func userFormModal(c echo.Context, user *models.User, isEdit bool, errorMessage string) templ.Component {
+	firm := middleware.GetCurrentFirm(c)
+	var customRoles []models.FirmRole
+	if firm != nil {
+		customRoles, _ = services.GetFirmRoles(db.DB, firm.ID)
+	}
+	registryLookup := services.RegistryLookupAvailable(db.DB, firm)
+	return partials.UserFormModal(c.Request().Context(), user, isEdit, customRoles, registryLookup, errorMessage)
+}
+
 // GetUserFormNew returns the form modal for creating a new user
 func GetUserFormNew(c echo.Context) error {
 	// Render the form modal with empty user
-	component := partials.UserFormModal(c.Request().Context(), nil, false, "")
+	component := userFormModal(c, nil, false, "")
 	return component.Render(c.Request().Context(), c.Response().Writer)
 }
 
@@ -651,7 +741,7 @@ func GetUserFormEdit(c echo.Context) error {
 	}
 
 	// Render the form modal with user data
-	component := partials.UserFormModal(c.Request().Context(), &user, true, "")
+	component := userFormModal(c, &user, true, "")
 	return component.Render(c.Request().Context(), c.Response().Writer)
 }
 
@@ -6,6 +6,7 @@ import (
 	"law_flow_app_go/models"
 	"law_flow_app_go/services"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
@@ -75,7 +76,8 @@ func RequireAuth() echo.MiddlewareFunc {
 	}
 }
 
-// RequireRole is middleware that requires specific roles
+// RequireRole is middleware that requires specific roles.
+// Users with a custom role match on its base role; routes open to custom roles use RequireCapability
 func RequireRole(roles ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -99,6 +101,52 @@ func RequireRole(roles ...string) echo.MiddlewareFunc {
 	}
 }
 
+// RequireCapability is middleware that requires a capability from the permission matrix,
+// granted by the user's built-in role or by their firm custom role
+func RequireCapability(capability string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := c.Get(ContextKeyUser).(*models.User)
+
+			if !user.Can(capability) {
+				return echo.NewHTTPError(http.StatusForbidden, "Insufficient permissions")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// ExternalPortalPath is the only area external collaborators can reach
+const ExternalPortalPath = "/external"
+
+// RestrictExternalUsers confines external collaborators to the external portal.
+// Firm routes branch on role for scoping, so an external account must never reach them.
+func RestrictExternalUsers() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := GetCurrentUser(c)
+			if user == nil || !user.IsExternal() {
+				return next(c)
+			}
+
+			path := c.Request().URL.Path
+			if path == ExternalPortalPath || strings.HasPrefix(path, ExternalPortalPath+"/") {
+				return next(c)
+			}
+
+			if c.Request().Header.Get("HX-Request") == "true" {
+				c.Response().Header().Set("HX-Redirect", ExternalPortalPath)
+				return c.NoContent(http.StatusForbidden)
+			}
+			if c.Request().Method == http.MethodGet && !strings.HasPrefix(path, "/api/") {
+				return c.Redirect(http.StatusSeeOther, ExternalPortalPath)
+			}
+			return echo.NewHTTPError(http.StatusForbidden, "Insufficient permissions")
+		}
+	}
+}
+
 // RequireSuperadmin is middleware that requires the user to be a superadmin
 // Unlike regular routes, this does NOT require a firm
 func RequireSuperadmin() echo.MiddlewareFunc {
@@ -162,6 +162,105 @@ func TestRequireRole(t *testing.T) {
 	})
 }
 
+func TestRequireCapability(t *testing.T) {
+	e := echo.New()
+	handler := RequireCapability(models.CapabilityCasesManage)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	paralegal := &models.FirmRole{Name: "Paralegal", BaseRole: "staff", Capabilities: "cases.view,cases.manage"}
+	accountant := &models.FirmRole{Name: "Accountant", BaseRole: "lawyer", Capabilities: "services.view,reports.export"}
+	paralegalID := "role-paralegal"
+
+	tests := []struct {
+		name   string
+		user   *models.User
+		status int
+	}{
+		{"BuiltInGranted", &models.User{Role: "lawyer"}, http.StatusOK},
+		{"BuiltInDenied", &models.User{Role: "staff"}, http.StatusForbidden},
+		{"CustomRoleGranted", &models.User{Role: "staff", CustomRole: paralegal}, http.StatusOK},
+		// The custom role decides, not the capabilities of its base role
+		{"CustomRoleDenied", &models.User{Role: "lawyer", CustomRole: accountant}, http.StatusForbidden},
+		// An unloaded custom role must not fall back to the base role
+		{"CustomRoleNotLoaded", &models.User{Role: "lawyer", CustomRoleID: &paralegalID}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(ContextKeyUser, tt.user)
+
+			err := handler(c)
+			if tt.status == http.StatusOK {
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			assert.Equal(t, tt.status, he.Code)
+		})
+	}
+}
+
+func TestRestrictExternalUsers(t *testing.T) {
+	e := echo.New()
+	handler := RestrictExternalUsers()(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	run := func(user *models.User, method, path string, htmx bool) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(method, path, nil)
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(ContextKeyUser, user)
+		return rec, handler(c)
+	}
+
+	external := &models.User{Role: "external"}
+
+	t.Run("FirmUsersPassThrough", func(t *testing.T) {
+		rec, err := run(&models.User{Role: "lawyer"}, http.MethodGet, "/cases", false)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("ExternalPortalAllowed", func(t *testing.T) {
+		for _, path := range []string{"/external", "/external/cases/abc"} {
+			rec, err := run(external, http.MethodGet, path, false)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code, path)
+		}
+	})
+
+	t.Run("PageRedirectsToPortal", func(t *testing.T) {
+		rec, err := run(external, http.MethodGet, "/dashboard", false)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusSeeOther, rec.Code)
+		assert.Equal(t, ExternalPortalPath, rec.Header().Get("Location"))
+	})
+
+	t.Run("HTMXRedirectsToPortal", func(t *testing.T) {
+		rec, err := run(external, http.MethodGet, "/api/cases/abc/documents", true)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Equal(t, ExternalPortalPath, rec.Header().Get("HX-Redirect"))
+	})
+
+	t.Run("APIForbidden", func(t *testing.T) {
+		for _, path := range []string{"/api/cases", "/externally-named"} {
+			_, err := run(external, http.MethodPost, path, false)
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok, path)
+			assert.Equal(t, http.StatusForbidden, he.Code)
+		}
+	})
+}
+
 func TestRequireSuperadmin(t *testing.T) {
 	e := echo.New()
 
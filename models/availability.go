@@ -7,7 +7,9 @@ import (
 	"gorm.io/gorm"
 )
 
-// Availability represents a lawyer's standard weekly working hours
+// Availability represents a lawyer's standard weekly working hours.
+// StartTime and EndTime are local wall-clock times in Timezone, so a 09:00 slot stays at 09:00
+// across daylight saving changes; they are resolved to absolute instants per date when generating slots.
 type Availability struct {
 	ID        string         `gorm:"type:uuid;primarykey" json:"id"`
 	CreatedAt time.Time      `json:"created_at"`
@@ -18,6 +20,7 @@ type Availability struct {
 	DayOfWeek int    `gorm:"not null" json:"day_of_week"`               // 0=Sunday...6=Saturday
 	StartTime string `gorm:"not null" json:"start_time"`                // "09:00" or "14:00"
 	EndTime   string `gorm:"not null" json:"end_time"`                  // "12:00" or "17:00"
+	Timezone  string `gorm:"size:64" json:"timezone"`                   // IANA zone the times are wall-clock in; empty = firm timezone
 	IsActive  bool   `gorm:"default:true" json:"is_active"`
 
 	// Relationships
@@ -9,9 +9,10 @@ import (
 
 // Case status constants
 const (
-	CaseStatusOpen   = "OPEN"
-	CaseStatusOnHold = "ON_HOLD"
-	CaseStatusClosed = "CLOSED"
+	CaseStatusOpen            = "OPEN"
+	CaseStatusOnHold          = "ON_HOLD"
+	CaseStatusClosed          = "CLOSED"
+	CaseStatusPendingApproval = "PENDING_APPROVAL" // High-risk intake awaiting a second admin approval
 )
 
 // Client role constants (role of client in the case)
@@ -45,6 +46,9 @@ type Case struct {
 	// Client's role in the case (demandante/demandado)
 	ClientRole *string `gorm:"size:20" json:"client_role,omitempty"`
 
+	// Amount in dispute (cuantía), in the firm's currency
+	AmountInDispute *float64 `json:"amount_in_dispute,omitempty"`
+
 	// Status and lifecycle
 	Status          string     `gorm:"not null;default:OPEN;index:idx_case_firm_status" json:"status"`
 	OpenedAt        time.Time  `gorm:"not null;index:idx_case_firm_opened" json:"opened_at"`
@@ -138,6 +142,11 @@ func (c *Case) IsOnHold() bool {
 	return c.Status == CaseStatusOnHold
 }
 
+// IsPendingApproval checks if the case is waiting for intake approval
+func (c *Case) IsPendingApproval() bool {
+	return c.Status == CaseStatusPendingApproval
+}
+
 // IsValidStatus checks if the status is valid
 func IsValidCaseStatus(status string) bool {
 	validStatuses := []string{
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Case approval statuses
+const (
+	CaseApprovalStatusPending  = "PENDING"
+	CaseApprovalStatusApproved = "APPROVED"
+	CaseApprovalStatusRejected = "REJECTED"
+)
+
+// Reasons a case requires intake approval
+const (
+	CaseApprovalReasonAmount   = "AMOUNT_THRESHOLD"
+	CaseApprovalReasonBranch   = "HIGH_RISK_BRANCH"
+	CaseApprovalReasonConflict = "CONFLICT_CHECK"
+)
+
+// CaseApproval records an intake approval request for a high-risk case and its decision
+type CaseApproval struct {
+	ID        string         `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Parent relationships
+	FirmID string `gorm:"type:uuid;not null;index" json:"firm_id"`
+	CaseID string `gorm:"type:uuid;not null;index" json:"case_id"`
+
+	// Request
+	RequestedByID   string  `gorm:"type:uuid;not null" json:"requested_by_id"`
+	Reasons         string  `gorm:"not null" json:"reasons"` // Comma-separated CaseApprovalReason* codes
+	ConflictDetails *string `gorm:"type:text" json:"conflict_details,omitempty"`
+
+	// Decision
+	Status        string     `gorm:"not null;default:PENDING;index" json:"status"`
+	DecidedByID   *string    `gorm:"type:uuid" json:"decided_by_id,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	DecisionNotes *string    `gorm:"type:text" json:"decision_notes,omitempty"`
+
+	// Relationships
+	Case        *Case `gorm:"foreignKey:CaseID" json:"case,omitempty"`
+	RequestedBy *User `gorm:"foreignKey:RequestedByID" json:"requested_by,omitempty"`
+	DecidedBy   *User `gorm:"foreignKey:DecidedByID" json:"decided_by,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID
+func (a *CaseApproval) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for CaseApproval model
+func (CaseApproval) TableName() string {
+	return "case_approvals"
+}
+
+// IsPending checks if the approval is still awaiting a decision
+func (a *CaseApproval) IsPending() bool {
+	return a.Status == CaseApprovalStatusPending
+}
+
+// GetReasons returns the reason codes as a slice
+func (a *CaseApproval) GetReasons() []string {
+	if a.Reasons == "" {
+		return nil
+	}
+	return strings.Split(a.Reasons, ",")
+}
@@ -33,9 +33,17 @@ type CaseDocument struct {
 	Description  *string `gorm:"type:text" json:"description,omitempty"`
 	IsPublic     bool    `gorm:"default:false" json:"is_public"` // If true, clients can view this document
 
+	SharedWithExternal bool `gorm:"default:false" json:"shared_with_external"` // If true, external collaborators on the case can view this document
+
 	// Upload tracking
 	UploadedByID *string `gorm:"type:uuid" json:"uploaded_by_id,omitempty"`
 	UploadedBy   *User   `gorm:"foreignKey:UploadedByID" json:"uploaded_by,omitempty"`
+
+	// Storage verification: FileMissingAt is set by the nightly check when the file is gone from storage
+	FileCheckedAt *time.Time `json:"file_checked_at,omitempty"`
+	FileMissingAt *time.Time `gorm:"index" json:"file_missing_at,omitempty"`
+
+	UnderLegalHold bool `gorm:"-" json:"under_legal_hold"` // Computed when listing; held documents cannot be deleted
 }
 
 // BeforeCreate hook to generate UUID
@@ -54,3 +62,8 @@ func (d *CaseDocument) GetDownloadURL() string {
 
 	return ""
 }
+
+// IsFileMissing reports whether the storage check found the file missing
+func (d *CaseDocument) IsFileMissing() bool {
+	return d.FileMissingAt != nil
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// External access statuses (derived from expiry and revocation)
+const (
+	ExternalAccessStatusActive  = "active"
+	ExternalAccessStatusExpired = "expired"
+	ExternalAccessStatusRevoked = "revoked"
+)
+
+// CaseExternalAccess grants a lawyer from another firm scoped, time-limited access to a single case.
+// The grantee signs in with a constrained "external" account that only sees documents and
+// log entries shared with external collaborators.
+type CaseExternalAccess struct {
+	ID        string    `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	FirmID string `gorm:"type:uuid;not null;index" json:"firm_id"`
+	CaseID string `gorm:"type:uuid;not null;index" json:"case_id"`
+	UserID string `gorm:"type:uuid;not null;index" json:"user_id"`
+
+	InvitedByID string     `gorm:"type:uuid;not null" json:"invited_by_id"`
+	ExpiresAt   time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	RevokedByID *string    `gorm:"type:uuid" json:"revoked_by_id,omitempty"`
+
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+
+	// Relationships
+	Case      *Case `gorm:"foreignKey:CaseID" json:"case,omitempty"`
+	User      *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	InvitedBy *User `gorm:"foreignKey:InvitedByID" json:"invited_by,omitempty"`
+	RevokedBy *User `gorm:"foreignKey:RevokedByID" json:"revoked_by,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID
+func (a *CaseExternalAccess) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for CaseExternalAccess model
+func (CaseExternalAccess) TableName() string {
+	return "case_external_accesses"
+}
+
+// Status returns whether the grant is active, expired or revoked at the given time
+func (a *CaseExternalAccess) Status(now time.Time) string {
+	if a.RevokedAt != nil {
+		return ExternalAccessStatusRevoked
+	}
+	if !a.ExpiresAt.After(now) {
+		return ExternalAccessStatusExpired
+	}
+	return ExternalAccessStatusActive
+}
+
+// IsActive checks if the grant currently allows access
+func (a *CaseExternalAccess) IsActive(now time.Time) bool {
+	return a.Status(now) == ExternalAccessStatusActive
+}
@@ -24,6 +24,8 @@ type CaseLog struct {
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
+
+	SharedWithExternal bool `gorm:"default:false"` // Visible to external collaborators on the case
 }
 
 // BeforeCreate hook to generate UUID
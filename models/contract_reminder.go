@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Contract reminder statuses
+const (
+	ContractStatusActive     = "ACTIVE"
+	ContractStatusRenewed    = "RENEWED"
+	ContractStatusTerminated = "TERMINATED"
+)
+
+// ContractReminder tracks a client contract's renewal date and the deadline to
+// send a termination notice before it renews
+type ContractReminder struct {
+	ID        string         `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Parent relationships
+	FirmID              string  `gorm:"type:uuid;not null;index" json:"firm_id"`
+	ClientID            string  `gorm:"type:uuid;not null;index" json:"client_id"`
+	ResponsibleLawyerID string  `gorm:"type:uuid;not null;index" json:"responsible_lawyer_id"`
+	CaseID              *string `gorm:"type:uuid;index" json:"case_id,omitempty"`
+
+	// Contract details
+	Title        string  `gorm:"not null" json:"title"`
+	Counterparty *string `json:"counterparty,omitempty"`
+	Notes        *string `gorm:"type:text" json:"notes,omitempty"`
+
+	// Renewal terms
+	RenewalDate        time.Time `gorm:"not null;index" json:"renewal_date"`
+	NoticeBusinessDays int       `gorm:"not null;default:0" json:"notice_business_days"` // Business days of notice required before RenewalDate
+	NoticeDeadline     time.Time `gorm:"not null;index" json:"notice_deadline"`          // Computed from RenewalDate and NoticeBusinessDays
+	AutoRenews         bool      `gorm:"not null;default:false" json:"auto_renews"`
+	RenewalTermMonths  int       `gorm:"not null;default:12" json:"renewal_term_months"`
+
+	// Alerting
+	AlertDaysBefore int        `gorm:"not null;default:30" json:"alert_days_before"` // Calendar days before NoticeDeadline
+	NotifyClient    bool       `gorm:"not null;default:true" json:"notify_client"`
+	AlertSentAt     *time.Time `json:"alert_sent_at,omitempty"`
+
+	Status string `gorm:"not null;default:ACTIVE;index" json:"status"`
+
+	// Relationships
+	Firm              *Firm `gorm:"foreignKey:FirmID" json:"firm,omitempty"`
+	Client            *User `gorm:"foreignKey:ClientID" json:"client,omitempty"`
+	ResponsibleLawyer *User `gorm:"foreignKey:ResponsibleLawyerID" json:"responsible_lawyer,omitempty"`
+	Case              *Case `gorm:"foreignKey:CaseID" json:"case,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID
+func (c *ContractReminder) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ContractReminder model
+func (ContractReminder) TableName() string {
+	return "contract_reminders"
+}
+
+// IsActive returns true if the contract is still being tracked for renewal
+func (c *ContractReminder) IsActive() bool {
+	return c.Status == ContractStatusActive
+}
+
+// AlertDate returns the day from which the renewal alert is sent
+func (c *ContractReminder) AlertDate() time.Time {
+	return c.NoticeDeadline.AddDate(0, 0, -c.AlertDaysBefore)
+}
+
+// IsNoticeOverdue returns true if the termination notice deadline has passed
+func (c *ContractReminder) IsNoticeOverdue(now time.Time) bool {
+	return c.IsActive() && now.After(c.NoticeDeadline.AddDate(0, 0, 1))
+}
+
+// DaysUntilDeadline returns the calendar days left until the notice deadline
+func (c *ContractReminder) DaysUntilDeadline(now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	deadline := time.Date(c.NoticeDeadline.Year(), c.NoticeDeadline.Month(), c.NoticeDeadline.Day(), 0, 0, 0, 0, time.UTC)
+	return int(deadline.Sub(today).Hours() / 24)
+}
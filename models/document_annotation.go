@@ -0,0 +1,102 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Annotation types
+const (
+	AnnotationTypeHighlight = "highlight"
+	AnnotationTypeComment   = "comment"
+	AnnotationTypePin       = "pin"
+)
+
+// DocumentAnnotation is a review note left on a page of a case PDF. Annotations are private
+// to their author unless shared, in which case the whole case team can see them.
+type DocumentAnnotation struct {
+	ID        string         `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Scope
+	FirmID     string `gorm:"type:uuid;not null;index" json:"firm_id"`
+	CaseID     string `gorm:"type:uuid;not null;index" json:"case_id"`
+	DocumentID string `gorm:"type:uuid;not null;index" json:"document_id"`
+	UserID     string `gorm:"type:uuid;not null;index" json:"user_id"` // Author
+
+	// Placement. Rects are relative to the page (0-1) so they survive zoom and rendering size:
+	// the highlighted areas for a highlight, a single point for a pin or comment
+	Type  string          `gorm:"size:20;not null" json:"type"`
+	Page  int             `gorm:"not null" json:"page"` // 1-based
+	Rects AnnotationRects `gorm:"type:text" json:"rects"`
+
+	// Content
+	Color        string `gorm:"size:20" json:"color,omitempty"`
+	SelectedText string `gorm:"type:text" json:"selected_text,omitempty"` // Text under a highlight
+	Comment      string `gorm:"type:text" json:"comment,omitempty"`
+
+	IsShared bool `gorm:"not null;default:false" json:"is_shared"` // Visible to the case team
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// AnnotationRect is an area of a page in page-relative coordinates
+type AnnotationRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// AnnotationRects stores the areas of an annotation as JSON in a text column
+type AnnotationRects []AnnotationRect
+
+func (r AnnotationRects) Value() (driver.Value, error) {
+	if len(r) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(r)
+}
+
+func (r *AnnotationRects) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*r = AnnotationRects{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	}
+	return errors.New("type assertion to []byte failed")
+}
+
+// BeforeCreate hook to generate UUID
+func (a *DocumentAnnotation) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for DocumentAnnotation model
+func (DocumentAnnotation) TableName() string {
+	return "document_annotations"
+}
+
+// IsValidAnnotationType checks if the annotation type is valid
+func IsValidAnnotationType(annotationType string) bool {
+	switch annotationType {
+	case AnnotationTypeHighlight, AnnotationTypeComment, AnnotationTypePin:
+		return true
+	}
+	return false
+}
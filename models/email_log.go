@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Email log delivery statuses
+const (
+	EmailLogStatusSent      = "SENT"
+	EmailLogStatusFailed    = "FAILED"
+	EmailLogStatusDelivered = "DELIVERED"
+	EmailLogStatusBounced   = "BOUNCED"
+)
+
+// Email log categories for firm-sent client notifications
+const (
+	EmailCategoryAppointmentConfirmation = "appointment_confirmation"
+	EmailCategoryContractRenewal         = "contract_renewal"
+)
+
+// EmailLog records a notification email sent by a firm to a client, with delivery
+// status and, when the firm allows it, open/click tracking reported by the provider
+type EmailLog struct {
+	ID        string         `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Scope
+	FirmID      string  `gorm:"type:uuid;not null;index" json:"firm_id"`
+	RecipientID *string `gorm:"type:uuid;index" json:"recipient_id,omitempty"`
+	CaseID      *string `gorm:"type:uuid;index" json:"case_id,omitempty"`
+
+	// Message
+	ToEmail           string  `gorm:"not null" json:"to_email"`
+	Subject           string  `gorm:"not null" json:"subject"`
+	Category          string  `gorm:"not null;index" json:"category"`
+	ProviderMessageID *string `gorm:"index" json:"provider_message_id,omitempty"`
+
+	// Delivery
+	Status      string     `gorm:"not null;default:SENT" json:"status"`
+	Error       *string    `gorm:"type:text" json:"error,omitempty"`
+	SentAt      time.Time  `gorm:"not null;index" json:"sent_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+
+	// Tracking (only recorded when the firm had tracking enabled at send time)
+	TrackingEnabled bool       `gorm:"not null;default:false" json:"tracking_enabled"`
+	OpenCount       int        `gorm:"not null;default:0" json:"open_count"`
+	FirstOpenedAt   *time.Time `json:"first_opened_at,omitempty"`
+	LastOpenedAt    *time.Time `json:"last_opened_at,omitempty"`
+	ClickCount      int        `gorm:"not null;default:0" json:"click_count"`
+	LastClickedAt   *time.Time `json:"last_clicked_at,omitempty"`
+	LastClickedURL  *string    `json:"last_clicked_url,omitempty"`
+
+	// Relationships
+	Recipient *User `gorm:"foreignKey:RecipientID" json:"recipient,omitempty"`
+	Case      *Case `gorm:"foreignKey:CaseID" json:"case,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID
+func (l *EmailLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for EmailLog model
+func (EmailLog) TableName() string {
+	return "email_logs"
+}
+
+// WasOpened checks if the recipient opened the email
+func (l *EmailLog) WasOpened() bool {
+	return l.OpenCount > 0
+}
@@ -39,6 +39,22 @@ type Firm struct {
 	BufferMinutes int    `gorm:"not null;default:15" json:"buffer_minutes"` // Buffer between appointments (30, 45, or 60 min)
 	Currency      string `gorm:"not null;default:'USD'" json:"currency"`    // Default currency for the firm
 
+	// Intake approval: new cases matching these criteria need a second admin's approval before activation
+	IntakeApprovalEnabled         bool     `gorm:"not null;default:false" json:"intake_approval_enabled"`
+	IntakeApprovalAmountThreshold *float64 `json:"intake_approval_amount_threshold,omitempty"`
+	IntakeApprovalBranchIDs       string   `gorm:"type:text" json:"intake_approval_branch_ids"` // Comma-separated CaseBranch IDs
+	IntakeApprovalOnConflict      bool     `gorm:"not null;default:true" json:"intake_approval_on_conflict"`
+
+	// Record provider-reported opens/clicks of client notification emails (off by default for privacy)
+	EmailTrackingEnabled bool `gorm:"not null;default:false" json:"email_tracking_enabled"`
+
+	// Prefill client and opposing party data from the country's public company registry (e.g. RUES)
+	RegistryLookupEnabled bool `gorm:"not null;default:false" json:"registry_lookup_enabled"`
+
+	// Stream downloads from storage through the app instead of redirecting to signed storage URLs.
+	// The embedded viewer always streams through the app
+	DocumentProxyEnabled bool `gorm:"not null;default:false" json:"document_proxy_enabled"`
+
 	// Relationships
 	Users        []User            `gorm:"foreignKey:FirmID" json:"-"`
 	Subscription *FirmSubscription `gorm:"foreignKey:FirmID" json:"subscription,omitempty"`
@@ -119,3 +135,24 @@ func generateRandomString(n int) string {
 func (Firm) TableName() string {
 	return "firms"
 }
+
+// GetIntakeApprovalBranchIDs returns the case branches that require intake approval
+func (f *Firm) GetIntakeApprovalBranchIDs() []string {
+	var ids []string
+	for _, id := range strings.Split(f.IntakeApprovalBranchIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// IsIntakeApprovalBranch checks if cases in the given branch require intake approval
+func (f *Firm) IsIntakeApprovalBranch(branchID string) bool {
+	for _, id := range f.GetIntakeApprovalBranchIDs() {
+		if id == branchID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomRoleBaseRoles are the built-in roles a custom role can build on
+var CustomRoleBaseRoles = []string{"lawyer", "staff"}
+
+// FirmRole is a firm-defined role such as "paralegal" or "accountant". Its capabilities
+// decide which modules its users reach; its base role decides how their records are scoped
+// (lawyers see the cases they work on, staff the whole firm) and is stored as the role of
+// its users, so role-based scoping keeps working unchanged.
+type FirmRole struct {
+	ID        string         `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	FirmID      string `gorm:"type:uuid;not null;index" json:"firm_id"`
+	Name        string `gorm:"size:100;not null" json:"name"`
+	Description string `gorm:"size:500" json:"description,omitempty"`
+	BaseRole    string `gorm:"size:20;not null" json:"base_role"`
+
+	// Comma-separated capability list, see Capabilities
+	Capabilities string `gorm:"type:text" json:"capabilities"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *FirmRole) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for FirmRole model
+func (FirmRole) TableName() string {
+	return "firm_roles"
+}
+
+// CapabilityList returns the role's capabilities
+func (r *FirmRole) CapabilityList() []string {
+	if r.Capabilities == "" {
+		return nil
+	}
+	return strings.Split(r.Capabilities, ",")
+}
+
+// HasCapability reports whether the role grants a capability
+func (r *FirmRole) HasCapability(capability string) bool {
+	for _, c := range r.CapabilityList() {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidCustomRoleBase checks if a built-in role can be used as a custom role base
+func IsValidCustomRoleBase(role string) bool {
+	for _, r := range CustomRoleBaseRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
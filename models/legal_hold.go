@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Legal hold statuses
+const (
+	LegalHoldStatusActive   = "active"
+	LegalHoldStatusReleased = "released"
+)
+
+// LegalHold preserves a case document, or every document and log entry of a case when
+// DocumentID is nil, against deletion until the hold is released. There are no retention
+// jobs yet; any purge added later must skip held documents as deletion does.
+// Holds are never deleted: released holds remain as the compliance record.
+type LegalHold struct {
+	ID        string    `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Scope
+	FirmID     string  `gorm:"type:uuid;not null;index" json:"firm_id"`
+	CaseID     string  `gorm:"type:uuid;not null;index" json:"case_id"`
+	DocumentID *string `gorm:"type:uuid;index" json:"document_id,omitempty"` // nil = whole case
+
+	// Hold details
+	Reason      string `gorm:"type:text;not null" json:"reason"`
+	CustodianID string `gorm:"type:uuid;not null;index" json:"custodian_id"` // Person responsible for preserving the records
+	PlacedByID  string `gorm:"type:uuid;not null" json:"placed_by_id"`
+
+	// Release
+	ReleasedAt    *time.Time `gorm:"index" json:"released_at,omitempty"`
+	ReleasedByID  *string    `gorm:"type:uuid" json:"released_by_id,omitempty"`
+	ReleaseReason *string    `gorm:"type:text" json:"release_reason,omitempty"`
+
+	// Relationships
+	Case       *Case         `gorm:"foreignKey:CaseID" json:"case,omitempty"`
+	Document   *CaseDocument `gorm:"foreignKey:DocumentID" json:"document,omitempty"`
+	Custodian  *User         `gorm:"foreignKey:CustodianID" json:"custodian,omitempty"`
+	PlacedBy   *User         `gorm:"foreignKey:PlacedByID" json:"placed_by,omitempty"`
+	ReleasedBy *User         `gorm:"foreignKey:ReleasedByID" json:"released_by,omitempty"`
+}
+
+// BeforeCreate hook to generate UUID
+func (h *LegalHold) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == "" {
+		h.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for LegalHold model
+func (LegalHold) TableName() string {
+	return "legal_holds"
+}
+
+// IsActive reports whether the hold still prevents deletion
+func (h *LegalHold) IsActive() bool {
+	return h.ReleasedAt == nil
+}
+
+// Status returns the hold status
+func (h *LegalHold) Status() string {
+	if h.IsActive() {
+		return LegalHoldStatusActive
+	}
+	return LegalHoldStatusReleased
+}
+
+// IsCaseWide reports whether the hold covers the whole case rather than a single document
+func (h *LegalHold) IsCaseWide() bool {
+	return h.DocumentID == nil
+}
@@ -8,9 +8,13 @@ import (
 
 // Notification types
 const (
-	NotificationTypeJudicialUpdate = "JUDICIAL_UPDATE"
-	NotificationTypeCaseUpdate     = "CASE_UPDATE"
-	NotificationTypeSystem         = "SYSTEM"
+	NotificationTypeJudicialUpdate  = "JUDICIAL_UPDATE"
+	NotificationTypeCaseUpdate      = "CASE_UPDATE"
+	NotificationTypeSystem          = "SYSTEM"
+	NotificationTypeContractRenewal = "CONTRACT_RENEWAL"
+	NotificationTypeApprovalRequest = "APPROVAL_REQUEST"
+	NotificationTypeLegalHold       = "LEGAL_HOLD"
+	NotificationTypeStorage         = "STORAGE"
 )
 
 type Notification struct {
@@ -0,0 +1,88 @@
+package models
+
+// Capabilities are the module-level permissions checked by routes and views. Built-in roles
+// get theirs from RoleCapabilities; firm custom roles are composed of a chosen subset.
+const (
+	CapabilityCasesView       = "cases.view"
+	CapabilityCasesManage     = "cases.manage"
+	CapabilityServicesView    = "services.view"
+	CapabilityServicesManage  = "services.manage"
+	CapabilityContractsView   = "contracts.view"
+	CapabilityContractsManage = "contracts.manage"
+	CapabilityCalendarView    = "calendar.view"
+	CapabilityCalendarManage  = "calendar.manage"
+	CapabilityCalendarFirm    = "calendar.firm"
+	CapabilityTemplatesManage = "templates.manage"
+	CapabilityReportsExport   = "reports.export"
+	CapabilitySearch          = "search"
+	CapabilityUsersView       = "users.view"
+)
+
+// Capabilities lists every capability in display order. Firm administration (settings,
+// billing, audit, compliance, approvals) is not a capability: it stays with the admin role.
+var Capabilities = []string{
+	CapabilityCasesView,
+	CapabilityCasesManage,
+	CapabilityServicesView,
+	CapabilityServicesManage,
+	CapabilityContractsView,
+	CapabilityContractsManage,
+	CapabilityCalendarView,
+	CapabilityCalendarManage,
+	CapabilityCalendarFirm,
+	CapabilityTemplatesManage,
+	CapabilityReportsExport,
+	CapabilitySearch,
+	CapabilityUsersView,
+}
+
+// RoleCapabilities is the permission matrix of the built-in roles. Lawyers work their own
+// calendar; admins and staff see everyone's.
+var RoleCapabilities = map[string][]string{
+	"admin": Capabilities,
+	"lawyer": {
+		CapabilityCasesView, CapabilityCasesManage,
+		CapabilityServicesView, CapabilityServicesManage,
+		CapabilityContractsView, CapabilityContractsManage,
+		CapabilityCalendarView, CapabilityCalendarManage,
+		CapabilityTemplatesManage, CapabilityReportsExport, CapabilitySearch, CapabilityUsersView,
+	},
+	"staff":  {CapabilityCalendarView, CapabilityCalendarFirm, CapabilitySearch},
+	"client": {CapabilityCasesView, CapabilityServicesView, CapabilityContractsView},
+}
+
+// impliedCapabilities maps a capability to the one it requires: managing a module means
+// being able to see it
+var impliedCapabilities = map[string]string{
+	CapabilityCasesManage:     CapabilityCasesView,
+	CapabilityServicesManage:  CapabilityServicesView,
+	CapabilityContractsManage: CapabilityContractsView,
+	CapabilityCalendarManage:  CapabilityCalendarView,
+	CapabilityCalendarFirm:    CapabilityCalendarView,
+}
+
+// IsValidCapability checks if the capability exists
+func IsValidCapability(capability string) bool {
+	for _, c := range Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// ImpliedCapability returns the capability required by the given one, if any
+func ImpliedCapability(capability string) (string, bool) {
+	implied, ok := impliedCapabilities[capability]
+	return implied, ok
+}
+
+// RoleHasCapability reports whether a built-in role is granted a capability
+func RoleHasCapability(role, capability string) bool {
+	for _, c := range RoleCapabilities[role] {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
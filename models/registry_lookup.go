@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RegistryLookup caches the answer of a public registry (e.g. RUES) for a tax ID.
+// Registry data is public, so the cache is shared by every firm in the same country.
+type RegistryLookup struct {
+	ID        string    `gorm:"type:uuid;primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Country   string    `gorm:"size:3;not null;uniqueIndex:idx_registry_lookup_country_tax" json:"country"` // ISO 3166-1 alpha-3
+	TaxID     string    `gorm:"size:30;not null;uniqueIndex:idx_registry_lookup_country_tax" json:"tax_id"`
+	Source    string    `gorm:"size:50;not null" json:"source"`
+	Found     bool      `gorm:"not null;default:false" json:"found"`
+	Data      string    `gorm:"type:text" json:"data"` // JSON-encoded registry.Record when found
+	FetchedAt time.Time `gorm:"not null" json:"fetched_at"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *RegistryLookup) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for RegistryLookup model
+func (RegistryLookup) TableName() string {
+	return "registry_lookups"
+}
@@ -47,6 +47,10 @@ type ServiceDocument struct {
 	// Upload tracking
 	UploadedByID *string `gorm:"type:uuid" json:"uploaded_by_id,omitempty"`
 	UploadedBy   *User   `gorm:"foreignKey:UploadedByID" json:"uploaded_by,omitempty"`
+
+	// Storage verification: FileMissingAt is set by the nightly check when the file is gone from storage
+	FileCheckedAt *time.Time `json:"file_checked_at,omitempty"`
+	FileMissingAt *time.Time `gorm:"index" json:"file_missing_at,omitempty"`
 }
 
 // BeforeCreate hook to generate UUID
@@ -116,3 +120,8 @@ func GetServiceDocTypeDisplayName(docType string) string {
 	}
 	return docType
 }
+
+// IsFileMissing reports whether the storage check found the file missing
+func (d *ServiceDocument) IsFileMissing() bool {
+	return d.FileMissingAt != nil
+}
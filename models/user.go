@@ -7,6 +7,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// Agenda digest frequencies
+const (
+	AgendaDigestDaily  = "daily"
+	AgendaDigestWeekly = "weekly"
+)
+
 type User struct {
 	ID        string         `gorm:"type:uuid;primarykey" json:"id"`
 	CreatedAt time.Time      `json:"created_at"`
@@ -17,11 +23,21 @@ type User struct {
 	Email       string     `gorm:"not null;uniqueIndex" json:"email"` // Unique globally
 	Password    string     `gorm:"not null" json:"-"`
 	FirmID      *string    `gorm:"type:uuid;index" json:"firm_id"`     // Nullable - user may not have firm yet
-	Role        string     `gorm:"not null;default:staff" json:"role"` // superadmin, admin, lawyer, staff, client
+	Role        string     `gorm:"not null;default:staff" json:"role"` // superadmin, admin, lawyer, staff, client, external
 	IsActive    bool       `gorm:"not null;default:true" json:"is_active"`
 	Language    string     `gorm:"not null;default:'es'" json:"language"` // en, es
 	LastLoginAt *time.Time `json:"last_login_at"`
 
+	// Firm custom role; Role then holds its base role. See FirmRole
+	CustomRoleID *string `gorm:"type:uuid;index" json:"custom_role_id,omitempty"`
+
+	// Morning agenda PDF by email: "" (off), daily, or weekly (sent on Mondays)
+	AgendaDigest string `gorm:"size:10" json:"agenda_digest"`
+
+	// Seat billing: a deactivated billable user keeps its paid seat until SeatHeldUntil
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	SeatHeldUntil *time.Time `json:"seat_held_until,omitempty"`
+
 	// Security / Lockout
 	FailedLoginAttempts int        `gorm:"default:0" json:"-"`
 	LockoutUntil        *time.Time `json:"-"`
@@ -36,6 +52,7 @@ type User struct {
 	// Relationships
 	Firm         *Firm         `gorm:"foreignKey:FirmID" json:"firm,omitempty"`
 	DocumentType *ChoiceOption `gorm:"foreignKey:DocumentTypeID" json:"document_type,omitempty"`
+	CustomRole   *FirmRole     `gorm:"foreignKey:CustomRoleID" json:"custom_role,omitempty"`
 }
 
 // BeforeCreate hook to generate UUID
@@ -56,7 +73,47 @@ func (u *User) IsSuperadmin() bool {
 	return u.Role == "superadmin"
 }
 
+// IsExternal checks if the user is an external collaborator limited to cases shared with them
+func (u *User) IsExternal() bool {
+	return u.Role == "external"
+}
+
+// Can reports whether the user holds a capability: from their custom role when they have
+// one, otherwise from the permission matrix of their role. A custom role that was not
+// loaded denies everything rather than falling back to the broader base role.
+func (u *User) Can(capability string) bool {
+	if u.CustomRole != nil {
+		return u.CustomRole.HasCapability(capability)
+	}
+	if u.CustomRoleID != nil {
+		return false
+	}
+	return RoleHasCapability(u.Role, capability)
+}
+
+// IsBillable checks if the user's role occupies a paid seat (clients and superadmins don't)
+func (u *User) IsBillable() bool {
+	return u.Role == "admin" || u.Role == "lawyer" || u.Role == "staff"
+}
+
+// HoldsSeat checks if the user currently occupies a paid seat.
+// Active billable users always do; deactivated ones only until their seat is released.
+func (u *User) HoldsSeat(now time.Time) bool {
+	if !u.IsBillable() {
+		return false
+	}
+	if u.IsActive {
+		return true
+	}
+	return u.SeatHeldUntil != nil && u.SeatHeldUntil.After(now)
+}
+
 // TableName specifies the table name for User model
 func (User) TableName() string {
 	return "users"
 }
+
+// IsValidAgendaDigest checks if an agenda digest frequency is valid (empty disables it)
+func IsValidAgendaDigest(frequency string) bool {
+	return frequency == "" || frequency == AgendaDigestDaily || frequency == AgendaDigestWeekly
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"law_flow_app_go/config"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Agenda periods
+const (
+	AgendaPeriodDay  = "day"
+	AgendaPeriodWeek = "week"
+)
+
+// Agenda item kinds
+const (
+	AgendaItemAppointment      = "appointment"
+	AgendaItemCaseMilestone    = "case_milestone"
+	AgendaItemServiceMilestone = "service_milestone"
+	AgendaItemContractNotice   = "contract_notice"
+)
+
+var ErrInvalidAgendaPeriod = errors.New("agenda period must be day or week")
+
+// AgendaItem is a single entry of a lawyer's agenda. Appointments (hearings included, as an
+// appointment type) have a time range; deadlines are all-day entries.
+type AgendaItem struct {
+	Kind      string
+	Start     time.Time
+	End       time.Time
+	AllDay    bool
+	Title     string
+	Detail    string // Appointment type name
+	Reference string // Case, service or contract reference
+}
+
+// AgendaDay groups the agenda items of one calendar day
+type AgendaDay struct {
+	Date  time.Time
+	Items []AgendaItem
+}
+
+// Agenda is a lawyer's schedule for a day or a week, in the firm's timezone
+type Agenda struct {
+	Lawyer   *models.User
+	FirmName string
+	Period   string
+	From     time.Time // Inclusive, local midnight
+	To       time.Time // Exclusive, local midnight
+	Location *time.Location
+	Days     []AgendaDay
+}
+
+// ItemCount returns the number of entries across all days
+func (a *Agenda) ItemCount() int {
+	count := 0
+	for _, day := range a.Days {
+		count += len(day.Items)
+	}
+	return count
+}
+
+// FileName returns the download name of the agenda PDF
+func (a *Agenda) FileName() string {
+	return fmt.Sprintf("agenda-%s-%s.pdf", a.Period, a.From.Format("2006-01-02"))
+}
+
+// AgendaRange returns the local-midnight bounds of the day or the Monday-based week containing date
+func AgendaRange(date time.Time, period string, loc *time.Location) (time.Time, time.Time, error) {
+	local := date.In(loc)
+	from := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	switch period {
+	case AgendaPeriodDay:
+		return from, from.AddDate(0, 0, 1), nil
+	case AgendaPeriodWeek:
+		offset := (int(from.Weekday()) + 6) % 7 // Days since Monday
+		from = from.AddDate(0, 0, -offset)
+		return from, from.AddDate(0, 0, 7), nil
+	}
+	return time.Time{}, time.Time{}, ErrInvalidAgendaPeriod
+}
+
+// BuildLawyerAgenda collects a lawyer's appointments and open deadlines for the day or week
+// containing date: case and service milestones on matters assigned to them and contract
+// notice deadlines they are responsible for
+func BuildLawyerAgenda(db *gorm.DB, firm *models.Firm, lawyer *models.User, date time.Time, period string) (*Agenda, error) {
+	loc := loadLocation(firm.Timezone)
+	from, to, err := AgendaRange(date, period, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	agenda := &Agenda{Lawyer: lawyer, FirmName: firm.Name, Period: period, From: from, To: to, Location: loc}
+	byDate := make(map[string][]AgendaItem)
+	add := func(day time.Time, item AgendaItem) {
+		key := day.Format("2006-01-02")
+		byDate[key] = append(byDate[key], item)
+	}
+
+	var appointments []models.Appointment
+	if err := db.Preload("AppointmentType").Preload("Case").
+		Where("firm_id = ? AND lawyer_id = ? AND start_time >= ? AND start_time < ?", firm.ID, lawyer.ID, from.UTC(), to.UTC()).
+		Where("status NOT IN (?)", []string{models.AppointmentStatusCancelled}).
+		Order("start_time ASC").
+		Find(&appointments).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch appointments: %w", err)
+	}
+	for _, apt := range appointments {
+		item := AgendaItem{Kind: AgendaItemAppointment, Start: apt.StartTime.In(loc), End: apt.EndTime.In(loc), Title: apt.ClientName}
+		if apt.AppointmentType != nil {
+			item.Detail = apt.AppointmentType.Name
+		}
+		if apt.Case != nil {
+			item.Reference = apt.Case.CaseNumber
+		}
+		add(item.Start, item)
+	}
+
+	// Due dates are calendar dates stored at UTC midnight
+	dueFrom := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	dueTo := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	openStatuses := []string{models.MilestoneStatusPending, models.MilestoneStatusInProgress}
+
+	var caseMilestones []models.CaseMilestone
+	if err := db.Preload("Case").
+		Where("firm_id = ? AND status IN ? AND due_date >= ? AND due_date < ?", firm.ID, openStatuses, dueFrom, dueTo).
+		Where(`case_id IN (SELECT c.id FROM cases c WHERE c.deleted_at IS NULL AND (c.assigned_to_id = ?
+			OR EXISTS (SELECT 1 FROM case_collaborators cc WHERE cc.case_id = c.id AND cc.user_id = ?)))`, lawyer.ID, lawyer.ID).
+		Order("due_date ASC").
+		Find(&caseMilestones).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch case milestones: %w", err)
+	}
+	for _, m := range caseMilestones {
+		add(*m.DueDate, AgendaItem{Kind: AgendaItemCaseMilestone, Start: *m.DueDate, AllDay: true, Title: m.Title, Reference: m.Case.CaseNumber})
+	}
+
+	var serviceMilestones []models.ServiceMilestone
+	if err := db.Preload("Service").
+		Where("firm_id = ? AND status IN ? AND due_date >= ? AND due_date < ?", firm.ID, openStatuses, dueFrom, dueTo).
+		Where("service_id IN (SELECT id FROM legal_services WHERE deleted_at IS NULL AND assigned_to_id = ?)", lawyer.ID).
+		Order("due_date ASC").
+		Find(&serviceMilestones).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch service milestones: %w", err)
+	}
+	for _, m := range serviceMilestones {
+		add(*m.DueDate, AgendaItem{Kind: AgendaItemServiceMilestone, Start: *m.DueDate, AllDay: true, Title: m.Title, Reference: m.Service.ServiceNumber})
+	}
+
+	var contracts []models.ContractReminder
+	if err := db.Where("firm_id = ? AND responsible_lawyer_id = ? AND status = ? AND notice_deadline >= ? AND notice_deadline < ?",
+		firm.ID, lawyer.ID, models.ContractStatusActive, dueFrom, dueTo).
+		Order("notice_deadline ASC").
+		Find(&contracts).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch contract deadlines: %w", err)
+	}
+	for _, contract := range contracts {
+		add(contract.NoticeDeadline, AgendaItem{Kind: AgendaItemContractNotice, Start: contract.NoticeDeadline, AllDay: true, Title: contract.Title})
+	}
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		items := byDate[day.Format("2006-01-02")]
+		// Deadlines first, then appointments in chronological order
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].AllDay != items[j].AllDay {
+				return items[i].AllDay
+			}
+			return items[i].Start.Before(items[j].Start)
+		})
+		agenda.Days = append(agenda.Days, AgendaDay{Date: day, Items: items})
+	}
+	return agenda, nil
+}
+
+var agendaTemplate = template.Must(template.New("agenda").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<style>
+    body { font-family: Helvetica, Arial, sans-serif; font-size: 10pt; color: #111; margin: 0; }
+    .header { border-bottom: 2px solid #111; padding-bottom: 8pt; margin-bottom: 14pt; }
+    .header h1 { font-size: 16pt; margin: 0 0 2pt 0; }
+    .header p { margin: 0; color: #555; }
+    .day { margin-bottom: 12pt; page-break-inside: avoid; }
+    .day h2 { font-size: 11pt; background: #f0f0f0; padding: 4pt 6pt; margin: 0 0 4pt 0; }
+    table { width: 100%; border-collapse: collapse; }
+    td { padding: 4pt 6pt; border-bottom: 1px solid #e5e5e5; vertical-align: top; }
+    td.time { width: 90pt; white-space: nowrap; font-weight: bold; }
+    td.kind { width: 110pt; color: #555; }
+    td.ref { width: 110pt; color: #555; text-align: right; }
+    .detail { color: #555; }
+    .empty { color: #888; font-style: italic; padding: 4pt 6pt; }
+    .footer { margin-top: 18pt; color: #888; font-size: 8pt; }
+</style>
+</head>
+<body>
+<div class="header">
+    <h1>{{.Title}}</h1>
+    <p>{{.Lawyer}} · {{.FirmName}} · {{.Range}}</p>
+</div>
+{{range .Days}}
+<div class="day">
+    <h2>{{.Label}}</h2>
+    {{if .Rows}}
+    <table>
+        {{range .Rows}}
+        <tr>
+            <td class="time">{{.Time}}</td>
+            <td class="kind">{{.Kind}}</td>
+            <td>{{.Title}}{{if .Detail}} <span class="detail">· {{.Detail}}</span>{{end}}</td>
+            <td class="ref">{{.Reference}}</td>
+        </tr>
+        {{end}}
+    </table>
+    {{else}}
+    <div class="empty">{{$.Empty}}</div>
+    {{end}}
+</div>
+{{end}}
+<div class="footer">{{.Generated}}</div>
+</body>
+</html>`))
+
+type agendaRow struct {
+	Time, Kind, Title, Detail, Reference string
+}
+
+type agendaDayView struct {
+	Label string
+	Rows  []agendaRow
+}
+
+// RenderAgendaHTML renders the agenda as a printable HTML page in the given language
+func RenderAgendaHTML(agenda *Agenda, lang string, now time.Time) (string, error) {
+	const dateFormat = "02/01/2006"
+	view := struct {
+		Title, Lawyer, FirmName, Range, Empty, Generated string
+		Days                                             []agendaDayView
+	}{
+		Title:    i18n.Translate(lang, "calendar.agenda.title_"+agenda.Period, nil),
+		FirmName: agenda.FirmName,
+		Empty:    i18n.Translate(lang, "calendar.agenda.empty_day", nil),
+		Generated: i18n.Translate(lang, "calendar.agenda.generated_at", map[string]interface{}{
+			"date": now.In(agenda.Location).Format(dateFormat + " 15:04"),
+		}),
+	}
+	if agenda.Lawyer != nil {
+		view.Lawyer = agenda.Lawyer.Name
+	}
+	view.Range = agenda.From.Format(dateFormat)
+	if last := agenda.To.AddDate(0, 0, -1); !last.Equal(agenda.From) {
+		view.Range += " – " + last.Format(dateFormat)
+	}
+
+	for _, day := range agenda.Days {
+		dayView := agendaDayView{
+			Label: i18n.Translate(lang, "availability.days."+strings.ToLower(day.Date.Weekday().String()), nil) + " " + day.Date.Format(dateFormat),
+		}
+		for _, item := range day.Items {
+			row := agendaRow{
+				Kind:      i18n.Translate(lang, "calendar.agenda.kinds."+item.Kind, nil),
+				Title:     item.Title,
+				Detail:    item.Detail,
+				Reference: item.Reference,
+			}
+			if item.AllDay {
+				row.Time = i18n.Translate(lang, "calendar.agenda.all_day", nil)
+			} else {
+				row.Time = item.Start.Format("15:04") + " – " + item.End.Format("15:04")
+			}
+			dayView.Rows = append(dayView.Rows, row)
+		}
+		view.Days = append(view.Days, dayView)
+	}
+
+	var buf bytes.Buffer
+	if err := agendaTemplate.Execute(&buf, view); err != nil {
+		return "", fmt.Errorf("failed to render agenda: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// GenerateAgendaPDF renders the agenda to a PDF
+func GenerateAgendaPDF(agenda *Agenda, lang string) ([]byte, error) {
+	html, err := RenderAgendaHTML(agenda, lang, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	options := DefaultPDFOptions()
+	options.MarginTop, options.MarginBottom, options.MarginLeft, options.MarginRight = 36, 36, 36, 36
+	return GeneratePDF(html, options)
+}
+
+// AgendaDigestHour is the local hour of the firm at which agenda digests are sent
+const AgendaDigestHour = 7
+
+// agendaDigestDue reports whether it is digest time in the firm's timezone
+func agendaDigestDue(firm *models.Firm, now time.Time) bool {
+	return now.In(loadLocation(firm.Timezone)).Hour() == AgendaDigestHour
+}
+
+// SendAgendaDigests emails each opted-in user their agenda PDF: today's for daily digests and
+// the current week's on Mondays for weekly ones. Empty agendas are not sent.
+// This should be run as an hourly job: each firm is sent its digests when its local time
+// reaches AgendaDigestHour
+func SendAgendaDigests(db *gorm.DB, now time.Time) error {
+	var users []models.User
+	if err := db.Preload("Firm").
+		Where("is_active = ? AND firm_id IS NOT NULL AND role IN ? AND agenda_digest IN ?",
+			true, []string{"admin", "lawyer", "staff"}, []string{models.AgendaDigestDaily, models.AgendaDigestWeekly}).
+		Find(&users).Error; err != nil {
+		return err
+	}
+
+	cfg := config.Load()
+	for i := range users {
+		user := &users[i]
+		if user.Firm == nil || !agendaDigestDue(user.Firm, now) {
+			continue
+		}
+
+		period := AgendaPeriodDay
+		if user.AgendaDigest == models.AgendaDigestWeekly {
+			if now.In(loadLocation(user.Firm.Timezone)).Weekday() != time.Monday {
+				continue
+			}
+			period = AgendaPeriodWeek
+		}
+
+		agenda, err := BuildLawyerAgenda(db, user.Firm, user, now, period)
+		if err != nil {
+			log.Printf("Error building agenda for user %s: %v", user.ID, err)
+			continue
+		}
+		if agenda.ItemCount() == 0 {
+			continue
+		}
+
+		lang := user.Language
+		if lang == "" {
+			lang = "es"
+		}
+		pdf, err := GenerateAgendaPDF(agenda, lang)
+		if err != nil {
+			log.Printf("Error generating agenda PDF for user %s: %v", user.ID, err)
+			continue
+		}
+
+		email := BuildAgendaDigestEmail(user.Email, AgendaDigestEmailData{
+			RecipientName: user.Name,
+			FirmName:      user.Firm.Name,
+			Period:        period,
+			Date:          agenda.From.Format("02/01/2006"),
+			ItemCount:     agenda.ItemCount(),
+			Link:          fmt.Sprintf("%s/calendar", cfg.AppURL),
+		}, lang)
+		email.Attachments = []Attachment{{Filename: agenda.FileName(), Content: pdf}}
+		SendEmailAsync(cfg, email)
+	}
+	return nil
+}
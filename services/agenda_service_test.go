@@ -0,0 +1,181 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAgendaTestDB(t *testing.T) (*gorm.DB, *models.Firm, *models.User, *models.User) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Firm{}, &models.User{}, &models.Case{}, &models.CaseMilestone{},
+		&models.LegalService{}, &models.ServiceMilestone{}, &models.ContractReminder{},
+		&models.AppointmentType{}, &models.Appointment{}))
+
+	firm := &models.Firm{Name: "Agenda Firm", BillingEmail: "billing@agenda.com", Timezone: "America/Bogota"}
+	require.NoError(t, db.Create(firm).Error)
+	lawyer := &models.User{FirmID: &firm.ID, Name: "Lawyer", Email: "lawyer@agenda.com", Role: "lawyer", IsActive: true}
+	require.NoError(t, db.Create(lawyer).Error)
+	other := &models.User{FirmID: &firm.ID, Name: "Other", Email: "other@agenda.com", Role: "lawyer", IsActive: true}
+	require.NoError(t, db.Create(other).Error)
+	return db, firm, lawyer, other
+}
+
+func TestAgendaRange(t *testing.T) {
+	loc, err := time.LoadLocation("America/Bogota")
+	require.NoError(t, err)
+
+	// Thursday evening in Bogota is already Friday in UTC
+	date := time.Date(2026, 3, 6, 2, 0, 0, 0, time.UTC)
+
+	from, to, err := AgendaRange(date, AgendaPeriodDay, loc)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 5, 0, 0, 0, 0, loc), from)
+	assert.Equal(t, time.Date(2026, 3, 6, 0, 0, 0, 0, loc), to)
+
+	from, to, err = AgendaRange(date, AgendaPeriodWeek, loc)
+	require.NoError(t, err)
+	assert.Equal(t, time.Monday, from.Weekday())
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, loc), from)
+	assert.Equal(t, time.Date(2026, 3, 9, 0, 0, 0, 0, loc), to)
+
+	_, _, err = AgendaRange(date, "month", loc)
+	assert.ErrorIs(t, err, ErrInvalidAgendaPeriod)
+}
+
+func TestAgendaDigestDue(t *testing.T) {
+	bogota := &models.Firm{Timezone: "America/Bogota"}
+	madrid := &models.Firm{Timezone: "Europe/Madrid"}
+
+	// 12:00 UTC is 07:00 in Bogota but already 13:00 in Madrid (CET, UTC+1)
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	assert.True(t, agendaDigestDue(bogota, now))
+	assert.False(t, agendaDigestDue(madrid, now))
+
+	// 06:00 UTC is Madrid's morning
+	now = time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC)
+	assert.True(t, agendaDigestDue(madrid, now))
+	assert.False(t, agendaDigestDue(bogota, now))
+}
+
+func TestBuildLawyerAgenda(t *testing.T) {
+	db, firm, lawyer, other := setupAgendaTestDB(t)
+	loc, _ := time.LoadLocation(firm.Timezone)
+	day := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	hearing := &models.AppointmentType{FirmID: firm.ID, Name: "Hearing"}
+	require.NoError(t, db.Create(hearing).Error)
+	ownCase := &models.Case{FirmID: firm.ID, ClientID: other.ID, CaseNumber: "AG-1", CaseType: "Civil",
+		Description: "Test", Status: models.CaseStatusOpen, OpenedAt: time.Now(), AssignedToID: &lawyer.ID}
+	require.NoError(t, db.Create(ownCase).Error)
+	sharedCase := &models.Case{FirmID: firm.ID, ClientID: other.ID, CaseNumber: "AG-2", CaseType: "Civil",
+		Description: "Test", Status: models.CaseStatusOpen, OpenedAt: time.Now(), AssignedToID: &other.ID}
+	require.NoError(t, db.Create(sharedCase).Error)
+	require.NoError(t, db.Model(sharedCase).Association("Collaborators").Append(lawyer))
+	otherCase := &models.Case{FirmID: firm.ID, ClientID: other.ID, CaseNumber: "AG-3", CaseType: "Civil",
+		Description: "Test", Status: models.CaseStatusOpen, OpenedAt: time.Now(), AssignedToID: &other.ID}
+	require.NoError(t, db.Create(otherCase).Error)
+
+	appointments := []models.Appointment{
+		// 14:00 Bogota time, on the requested day
+		{FirmID: firm.ID, LawyerID: lawyer.ID, AppointmentTypeID: &hearing.ID, CaseID: &ownCase.ID, ClientName: "Acme",
+			ClientEmail: "acme@example.com", StartTime: time.Date(2026, 3, 5, 14, 0, 0, 0, loc), EndTime: time.Date(2026, 3, 5, 15, 0, 0, 0, loc)},
+		// 21:00 Bogota time is 02:00 UTC the next day but still on the requested local day
+		{FirmID: firm.ID, LawyerID: lawyer.ID, ClientName: "Late", ClientEmail: "late@example.com",
+			StartTime: time.Date(2026, 3, 5, 21, 0, 0, 0, loc), EndTime: time.Date(2026, 3, 5, 21, 30, 0, 0, loc)},
+		{FirmID: firm.ID, LawyerID: lawyer.ID, ClientName: "Cancelled", ClientEmail: "c@example.com", Status: models.AppointmentStatusCancelled,
+			StartTime: time.Date(2026, 3, 5, 9, 0, 0, 0, loc), EndTime: time.Date(2026, 3, 5, 10, 0, 0, 0, loc)},
+		{FirmID: firm.ID, LawyerID: other.ID, ClientName: "Not mine", ClientEmail: "n@example.com",
+			StartTime: time.Date(2026, 3, 5, 9, 0, 0, 0, loc), EndTime: time.Date(2026, 3, 5, 10, 0, 0, 0, loc)},
+	}
+	for i := range appointments {
+		require.NoError(t, db.Create(&appointments[i]).Error)
+	}
+
+	milestones := []models.CaseMilestone{
+		{FirmID: firm.ID, CaseID: ownCase.ID, Title: "File answer", Status: models.MilestoneStatusPending, DueDate: &day},
+		{FirmID: firm.ID, CaseID: sharedCase.ID, Title: "Shared deadline", Status: models.MilestoneStatusInProgress, DueDate: &day},
+		{FirmID: firm.ID, CaseID: ownCase.ID, Title: "Done", Status: models.MilestoneStatusCompleted, DueDate: &day},
+		{FirmID: firm.ID, CaseID: otherCase.ID, Title: "Someone else's", Status: models.MilestoneStatusPending, DueDate: &day},
+	}
+	for i := range milestones {
+		require.NoError(t, db.Create(&milestones[i]).Error)
+	}
+
+	service := &models.LegalService{FirmID: firm.ID, ServiceNumber: "SVC-1", Title: "Advice", ClientID: other.ID, Objective: "Test", AssignedToID: &lawyer.ID}
+	require.NoError(t, db.Create(service).Error)
+	require.NoError(t, db.Create(&models.ServiceMilestone{FirmID: firm.ID, ServiceID: service.ID, Title: "Deliver memo",
+		Status: models.MilestoneStatusPending, DueDate: &day}).Error)
+
+	require.NoError(t, db.Create(&models.ContractReminder{FirmID: firm.ID, ClientID: other.ID, ResponsibleLawyerID: lawyer.ID,
+		Title: "Lease", RenewalDate: day.AddDate(0, 1, 0), NoticeDeadline: day, Status: models.ContractStatusActive}).Error)
+
+	agenda, err := BuildLawyerAgenda(db, firm, lawyer, time.Date(2026, 3, 5, 12, 0, 0, 0, loc), AgendaPeriodDay)
+	require.NoError(t, err)
+	require.Len(t, agenda.Days, 1)
+	items := agenda.Days[0].Items
+	require.Len(t, items, 6)
+
+	// Deadlines come first, then appointments by start time
+	for _, item := range items[:4] {
+		assert.True(t, item.AllDay)
+	}
+	assert.Equal(t, AgendaItemAppointment, items[4].Kind)
+	assert.Equal(t, "Hearing", items[4].Detail)
+	assert.Equal(t, "AG-1", items[4].Reference)
+	assert.Equal(t, "Late", items[5].Title)
+
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	assert.ElementsMatch(t, []string{"File answer", "Shared deadline", "Deliver memo", "Lease", "Acme", "Late"}, titles)
+
+	// A week always lists seven days, empty ones included
+	week, err := BuildLawyerAgenda(db, firm, lawyer, time.Date(2026, 3, 5, 12, 0, 0, 0, loc), AgendaPeriodWeek)
+	require.NoError(t, err)
+	require.Len(t, week.Days, 7)
+	assert.Equal(t, 6, week.ItemCount())
+	assert.Empty(t, week.Days[0].Items)
+	assert.Len(t, week.Days[3].Items, 6)
+	assert.Equal(t, "agenda-week-2026-03-02.pdf", week.FileName())
+}
+
+func TestRenderAgendaHTML(t *testing.T) {
+	i18n.Load()
+	loc, _ := time.LoadLocation("America/Bogota")
+	from := time.Date(2026, 3, 5, 0, 0, 0, 0, loc)
+	agenda := &Agenda{
+		Lawyer:   &models.User{Name: "Ana <Lawyer>"},
+		FirmName: "Agenda Firm",
+		Period:   AgendaPeriodDay,
+		From:     from,
+		To:       from.AddDate(0, 0, 1),
+		Location: loc,
+		Days: []AgendaDay{{Date: from, Items: []AgendaItem{
+			{Kind: AgendaItemContractNotice, Start: from, AllDay: true, Title: "Lease"},
+			{Kind: AgendaItemAppointment, Start: from.Add(14 * time.Hour), End: from.Add(15 * time.Hour), Title: "Acme", Detail: "Hearing", Reference: "AG-1"},
+		}}},
+	}
+
+	html, err := RenderAgendaHTML(agenda, "en", from)
+	require.NoError(t, err)
+	assert.Contains(t, html, "Daily Agenda")
+	assert.Contains(t, html, "Thursday 05/03/2026")
+	assert.Contains(t, html, "14:00 – 15:00")
+	assert.Contains(t, html, "All day")
+	assert.Contains(t, html, "Contract notice deadline")
+	assert.Contains(t, html, "Ana &lt;Lawyer&gt;", "user data is escaped")
+
+	html, err = RenderAgendaHTML(agenda, "es", from)
+	require.NoError(t, err)
+	assert.Contains(t, html, "Agenda Diaria")
+	assert.Contains(t, html, "Todo el día")
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"law_flow_app_go/models"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
@@ -181,7 +182,9 @@ func CheckAppointmentConflict(db *gorm.DB, lawyerID string, startTime, endTime t
 	return count > 0, nil
 }
 
-// GetAvailableSlots generates available time slots for a lawyer on a specific date
+// GetAvailableSlots generates available time slots for a lawyer on a specific date.
+// The date is a calendar day in the firm's timezone; each availability slot is resolved in
+// its own timezone, so slots keep their local hours across daylight saving transitions.
 func GetAvailableSlots(db *gorm.DB, lawyerID string, date time.Time, slotDurationMinutes int, firmTimezone string) ([]models.TimeSlot, error) {
 	// Load timezone
 	loc, err := time.LoadLocation(firmTimezone)
@@ -189,16 +192,15 @@ func GetAvailableSlots(db *gorm.DB, lawyerID string, date time.Time, slotDuratio
 		loc = time.UTC
 	}
 
-	// Get the start and end of the day in the firm's timezone
+	// Get the start and end of the day in the firm's timezone. AddDate keeps the end at
+	// local midnight on days that are 23 or 25 hours long.
 	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
-	dayEnd := dayStart.Add(24 * time.Hour)
+	dayEnd := dayStart.AddDate(0, 0, 1)
 
-	// Get the day of week (0=Sunday, 6=Saturday)
-	dayOfWeek := int(dayStart.Weekday())
-
-	// 1. Get availability slots for this day
+	// 1. Get the lawyer's availability slots. Their weekday is evaluated in each slot's own
+	// timezone below, which may differ from the firm's.
 	var availabilities []models.Availability
-	err = db.Where("lawyer_id = ? AND day_of_week = ? AND is_active = ?", lawyerID, dayOfWeek, true).
+	err = db.Where("lawyer_id = ? AND is_active = ?", lawyerID, true).
 		Order("start_time").
 		Find(&availabilities).Error
 	if err != nil {
@@ -206,7 +208,7 @@ func GetAvailableSlots(db *gorm.DB, lawyerID string, date time.Time, slotDuratio
 	}
 
 	if len(availabilities) == 0 {
-		return []models.TimeSlot{}, nil // No availability for this day
+		return []models.TimeSlot{}, nil // No availability configured
 	}
 
 	// 2. Get blocked dates overlapping with this day
@@ -224,59 +226,73 @@ func GetAvailableSlots(db *gorm.DB, lawyerID string, date time.Time, slotDuratio
 	// 4. Generate all possible slots within availability windows
 	var availableSlots []models.TimeSlot
 	slotDuration := time.Duration(slotDurationMinutes) * time.Minute
+	if slotDuration <= 0 {
+		return []models.TimeSlot{}, nil
+	}
 
 	for _, avail := range availabilities {
-		// Parse availability times (format "HH:MM")
-		availStart, _ := time.Parse("15:04", avail.StartTime)
-		availEnd, _ := time.Parse("15:04", avail.EndTime)
-
-		// Create full datetime for this availability window
-		windowStart := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(),
-			availStart.Hour(), availStart.Minute(), 0, 0, loc)
-		windowEnd := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(),
-			availEnd.Hour(), availEnd.Minute(), 0, 0, loc)
-
-		// Generate slots within this window
-		for slotStart := windowStart; slotStart.Add(slotDuration).Before(windowEnd) || slotStart.Add(slotDuration).Equal(windowEnd); slotStart = slotStart.Add(slotDuration) {
-			slotEnd := slotStart.Add(slotDuration)
-
-			// Convert to UTC for comparison
-			slotStartUTC := slotStart.UTC()
-			slotEndUTC := slotEnd.UTC()
-
-			// Check if slot is blocked
-			isBlocked := false
-			for _, blocked := range blockedDates {
-				if blocked.IsBlocking(slotStartUTC, slotEndUTC) {
-					isBlocked = true
-					break
-				}
-			}
-			if isBlocked {
+		availLoc := AvailabilityLocation(avail, loc)
+
+		// The firm's day may span two calendar days in the slot's timezone
+		first := dayStart.In(availLoc)
+		last := dayEnd.Add(-time.Nanosecond).In(availLoc)
+		firstDay := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+		lastDay := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+		for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+			windowStart, windowEnd, ok := AvailabilityWindow(avail, day.Year(), day.Month(), day.Day(), loc)
+			if !ok {
 				continue
 			}
 
-			// Check if slot conflicts with existing appointment
-			hasConflict := false
-			for _, apt := range existingAppointments {
-				// Overlap check: (StartA < EndB) AND (EndA > StartB)
-				if apt.StartTime.Before(slotEndUTC) && apt.EndTime.After(slotStartUTC) {
-					hasConflict = true
-					break
+			// Generate slots within this window, keeping only those on the requested day
+			for slotStart := windowStart; !slotStart.Add(slotDuration).After(windowEnd); slotStart = slotStart.Add(slotDuration) {
+				slotEnd := slotStart.Add(slotDuration)
+				if slotStart.Before(dayStart) || slotEnd.After(dayEnd) {
+					continue
 				}
-			}
-			if hasConflict {
-				continue
-			}
 
-			// Slot is available - store in UTC
-			availableSlots = append(availableSlots, models.TimeSlot{
-				StartTime: slotStartUTC,
-				EndTime:   slotEndUTC,
-			})
+				// Convert to UTC for comparison
+				slotStartUTC := slotStart.UTC()
+				slotEndUTC := slotEnd.UTC()
+
+				// Check if slot is blocked
+				isBlocked := false
+				for _, blocked := range blockedDates {
+					if blocked.IsBlocking(slotStartUTC, slotEndUTC) {
+						isBlocked = true
+						break
+					}
+				}
+				if isBlocked {
+					continue
+				}
+
+				// Check if slot conflicts with existing appointment
+				hasConflict := false
+				for _, apt := range existingAppointments {
+					// Overlap check: (StartA < EndB) AND (EndA > StartB)
+					if apt.StartTime.Before(slotEndUTC) && apt.EndTime.After(slotStartUTC) {
+						hasConflict = true
+						break
+					}
+				}
+				if hasConflict {
+					continue
+				}
+
+				// Slot is available - store in UTC
+				availableSlots = append(availableSlots, models.TimeSlot{
+					StartTime: slotStartUTC,
+					EndTime:   slotEndUTC,
+				})
+			}
 		}
 	}
 
+	sort.Slice(availableSlots, func(i, j int) bool {
+		return availableSlots[i].StartTime.Before(availableSlots[j].StartTime)
+	})
+
 	return availableSlots, nil
 }
 
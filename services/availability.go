@@ -31,13 +31,16 @@ var defaultAvailabilitySlots = []struct {
 }
 
 // CreateDefaultAvailability creates the default availability slots for a lawyer
+// in their firm's timezone
 func CreateDefaultAvailability(db *gorm.DB, lawyerID string) error {
+	timezone := LawyerTimezone(db, lawyerID)
 	for _, slot := range defaultAvailabilitySlots {
 		availability := &models.Availability{
 			LawyerID:  lawyerID,
 			DayOfWeek: slot.DayOfWeek,
 			StartTime: slot.StartTime,
 			EndTime:   slot.EndTime,
+			Timezone:  timezone,
 			IsActive:  true,
 		}
 		if err := db.Create(availability).Error; err != nil {
@@ -66,8 +69,12 @@ func GetAvailabilityByID(db *gorm.DB, id string) (*models.Availability, error) {
 	return &slot, nil
 }
 
-// CreateAvailabilitySlot adds a new availability slot
+// CreateAvailabilitySlot adds a new availability slot. Slots without an explicit
+// timezone are pinned to the lawyer's firm timezone at creation.
 func CreateAvailabilitySlot(db *gorm.DB, slot *models.Availability) error {
+	if slot.Timezone == "" {
+		slot.Timezone = LawyerTimezone(db, slot.LawyerID)
+	}
 	return db.Create(slot).Error
 }
 
@@ -124,20 +131,25 @@ func DeleteBlockedDate(db *gorm.DB, id string) error {
 // IsTimeSlotAvailable checks if a time slot is available for a lawyer
 // It considers weekly availability, blocked dates, and existing appointments
 func IsTimeSlotAvailable(db *gorm.DB, lawyerID string, checkStart, checkEnd time.Time) (bool, error) {
-	// 1. Check if the time falls within regular availability
-	dayOfWeek := int(checkStart.Weekday())
-	startTimeStr := checkStart.Format("15:04")
-	endTimeStr := checkEnd.Format("15:04")
-
-	var count int64
-	err := db.Model(&models.Availability{}).
-		Where("lawyer_id = ? AND day_of_week = ? AND is_active = ? AND start_time <= ? AND end_time >= ?",
-			lawyerID, dayOfWeek, true, startTimeStr, endTimeStr).
-		Count(&count).Error
+	// 1. Check if the time falls within regular availability. Slots are wall-clock times in
+	// their own timezone, so each one is resolved on the date the check falls on locally.
+	var availabilities []models.Availability
+	err := db.Where("lawyer_id = ? AND is_active = ?", lawyerID, true).Find(&availabilities).Error
 	if err != nil {
 		return false, err
 	}
-	if count == 0 {
+
+	fallback := loadLocation(LawyerTimezone(db, lawyerID))
+	withinHours := false
+	for _, avail := range availabilities {
+		local := checkStart.In(AvailabilityLocation(avail, fallback))
+		windowStart, windowEnd, ok := AvailabilityWindow(avail, local.Year(), local.Month(), local.Day(), fallback)
+		if ok && !checkStart.Before(windowStart) && !checkEnd.After(windowEnd) {
+			withinHours = true
+			break
+		}
+	}
+	if !withinHours {
 		return false, nil // Not within regular working hours
 	}
 
@@ -227,3 +239,116 @@ func CheckBlockedDateOverlap(db *gorm.DB, lawyerID string, startAt, endAt time.T
 
 	return count > 0, nil
 }
+
+// LawyerTimezone returns the timezone of the lawyer's firm, used for availability
+// slots saved without one
+func LawyerTimezone(db *gorm.DB, lawyerID string) string {
+	var timezone string
+	db.Model(&models.Firm{}).
+		Select("firms.timezone").
+		Joins("JOIN users ON users.firm_id = firms.id").
+		Where("users.id = ?", lawyerID).
+		Scan(&timezone)
+	if timezone == "" {
+		return "UTC"
+	}
+	return timezone
+}
+
+// AvailabilityLocation returns the timezone a slot's wall-clock times are expressed in,
+// falling back to the given location for slots saved before timezones were stored
+func AvailabilityLocation(avail models.Availability, fallback *time.Location) *time.Location {
+	if avail.Timezone != "" {
+		if loc, err := time.LoadLocation(avail.Timezone); err == nil {
+			return loc
+		}
+	}
+	if fallback == nil {
+		return time.UTC
+	}
+	return fallback
+}
+
+// AvailabilityWindow resolves a weekly slot to absolute instants on a calendar date of the
+// slot's timezone. ok is false when the slot does not apply to that date's weekday or the
+// window vanishes entirely inside a daylight saving gap.
+//
+// Wall-clock times are resolved DST-safely: a time skipped by a spring-forward transition
+// moves to the first instant after the gap, and a time repeated by a fall-back transition
+// resolves to its first occurrence for the start and its last for the end, so the window
+// covers every instant whose local time lies inside it.
+func AvailabilityWindow(avail models.Availability, year int, month time.Month, day int, fallback *time.Location) (start, end time.Time, ok bool) {
+	loc := AvailabilityLocation(avail, fallback)
+	if int(time.Date(year, month, day, 12, 0, 0, 0, loc).Weekday()) != avail.DayOfWeek {
+		return time.Time{}, time.Time{}, false
+	}
+
+	startClock, err := time.Parse("15:04", avail.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	endClock, err := time.Parse("15:04", avail.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start = resolveWallClock(year, month, day, startClock.Hour(), startClock.Minute(), loc, false)
+	end = resolveWallClock(year, month, day, endClock.Hour(), endClock.Minute(), loc, true)
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// resolveWallClock returns the instant a local wall-clock time refers to in loc. Times inside a
+// spring-forward gap resolve to the end of the gap; times repeated by a fall-back transition
+// resolve to their earliest occurrence, or their latest when latest is set.
+func resolveWallClock(year int, month time.Month, day, hour, minute int, loc *time.Location, latest bool) time.Time {
+	t := time.Date(year, month, day, hour, minute, 0, 0, loc)
+
+	_, offsetBefore := t.Add(-3 * time.Hour).Zone()
+	_, offsetAfter := t.Add(3 * time.Hour).Zone()
+	if offsetBefore == offsetAfter {
+		return t // No transition nearby
+	}
+	shift := time.Duration(offsetAfter-offsetBefore) * time.Second
+
+	isWallClock := func(u time.Time) bool {
+		y, m, d := u.Date()
+		return y == year && m == month && d == day && u.Hour() == hour && u.Minute() == minute
+	}
+
+	if shift > 0 {
+		// Spring forward: the wall-clock time may not exist; use the transition instant
+		if isWallClock(t) {
+			return t
+		}
+		for u := t.Add(-shift); !u.After(t.Add(shift)); u = u.Add(time.Minute) {
+			if _, offset := u.Zone(); offset == offsetAfter {
+				return u
+			}
+		}
+		return t
+	}
+
+	// Fall back: the wall-clock time may occur twice, one shift apart
+	resolved := t
+	for _, candidate := range []time.Time{t.Add(shift), t.Add(-shift)} {
+		if !isWallClock(candidate) {
+			continue
+		}
+		if (latest && candidate.After(resolved)) || (!latest && candidate.Before(resolved)) {
+			resolved = candidate
+		}
+	}
+	return resolved
+}
+
+// loadLocation loads an IANA timezone, defaulting to UTC when it is unknown
+func loadLocation(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
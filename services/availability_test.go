@@ -85,3 +85,153 @@ func TestAvailabilityService(t *testing.T) {
 		assert.False(t, noOverlap)
 	})
 }
+
+func TestAvailabilityAcrossDSTTransitions(t *testing.T) {
+	db := setupAvailabilityTestDB(t)
+	firmID := "firm-dst"
+	lawyerID := "lawyer-dst"
+	db.Create(&models.Firm{ID: firmID, Timezone: "America/New_York"})
+	db.Create(&models.User{ID: lawyerID, Email: "lawyer@dst.test", FirmID: &firmID})
+	assert.NoError(t, CreateDefaultAvailability(db, lawyerID))
+
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	t.Run("Default slots are pinned to the firm timezone", func(t *testing.T) {
+		slots, err := GetLawyerAvailability(db, lawyerID)
+		assert.NoError(t, err)
+		for _, slot := range slots {
+			assert.Equal(t, "America/New_York", slot.Timezone)
+		}
+	})
+
+	t.Run("IsTimeSlotAvailable keeps local hours through spring forward week", func(t *testing.T) {
+		// DST starts Sunday March 8th 2026: 09:00 is 14:00 UTC before and 13:00 UTC after
+		friday := time.Date(2026, 3, 6, 14, 0, 0, 0, time.UTC)
+		monday := time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC)
+
+		available, err := IsTimeSlotAvailable(db, lawyerID, friday, friday.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.True(t, available)
+
+		available, err = IsTimeSlotAvailable(db, lawyerID, monday, monday.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.True(t, available)
+
+		// 13:00 UTC on the Friday is still 08:00 local
+		early := time.Date(2026, 3, 6, 13, 0, 0, 0, time.UTC)
+		available, err = IsTimeSlotAvailable(db, lawyerID, early, early.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.False(t, available)
+	})
+
+	t.Run("IsTimeSlotAvailable keeps local hours through fall back week", func(t *testing.T) {
+		// DST ends Sunday November 1st 2026: 16:00 is 20:00 UTC before and 21:00 UTC after
+		friday := time.Date(2026, 10, 30, 20, 0, 0, 0, time.UTC)
+		monday := time.Date(2026, 11, 2, 21, 0, 0, 0, time.UTC)
+
+		available, err := IsTimeSlotAvailable(db, lawyerID, friday, friday.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.True(t, available)
+
+		available, err = IsTimeSlotAvailable(db, lawyerID, monday, monday.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.True(t, available)
+
+		// 21:00 UTC on the Friday is already 17:00 local
+		late := time.Date(2026, 10, 30, 21, 0, 0, 0, time.UTC)
+		available, err = IsTimeSlotAvailable(db, lawyerID, late, late.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.False(t, available)
+	})
+
+	t.Run("GetAvailableSlots yields the same local hours either side of a transition", func(t *testing.T) {
+		for _, date := range []time.Time{
+			time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC),
+			time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+		} {
+			slots, err := GetAvailableSlots(db, lawyerID, date, 60, "America/New_York")
+			assert.NoError(t, err)
+
+			var hours []string
+			for _, s := range slots {
+				hours = append(hours, s.StartTime.In(newYork).Format("15:04"))
+			}
+			assert.Equal(t, []string{"09:00", "10:00", "11:00", "14:00", "15:00", "16:00"}, hours, date.Format("2006-01-02"))
+		}
+	})
+
+	t.Run("AvailabilityWindow on transition days", func(t *testing.T) {
+		sunday := models.Availability{DayOfWeek: 0, StartTime: "01:00", EndTime: "04:00", Timezone: "America/New_York"}
+
+		// Spring forward: 02:00-03:00 does not exist, so the window is two hours long
+		start, end, ok := AvailabilityWindow(sunday, 2026, time.March, 8, time.UTC)
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2026, 3, 8, 6, 0, 0, 0, time.UTC), start.UTC())
+		assert.Equal(t, time.Date(2026, 3, 8, 8, 0, 0, 0, time.UTC), end.UTC())
+
+		// Fall back: 01:00-02:00 happens twice, so the window is four hours long
+		start, end, ok = AvailabilityWindow(sunday, 2026, time.November, 1, time.UTC)
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2026, 11, 1, 5, 0, 0, 0, time.UTC), start.UTC())
+		assert.Equal(t, time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC), end.UTC())
+
+		// A start inside the gap moves to the end of the gap
+		inGap := models.Availability{DayOfWeek: 0, StartTime: "02:30", EndTime: "03:30", Timezone: "America/New_York"}
+		start, end, ok = AvailabilityWindow(inGap, 2026, time.March, 8, time.UTC)
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC), start.UTC())
+		assert.Equal(t, time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC), end.UTC())
+
+		// A window entirely inside the gap does not occur that day
+		_, _, ok = AvailabilityWindow(models.Availability{DayOfWeek: 0, StartTime: "02:00", EndTime: "02:45", Timezone: "America/New_York"}, 2026, time.March, 8, time.UTC)
+		assert.False(t, ok)
+
+		// An ambiguous end resolves to its last occurrence
+		_, end, ok = AvailabilityWindow(models.Availability{DayOfWeek: 0, StartTime: "00:00", EndTime: "01:30", Timezone: "America/New_York"}, 2026, time.November, 1, time.UTC)
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC), end.UTC())
+
+		// Wrong weekday
+		_, _, ok = AvailabilityWindow(sunday, 2026, time.March, 9, time.UTC)
+		assert.False(t, ok)
+	})
+
+	t.Run("Slot timezone takes precedence over the firm timezone", func(t *testing.T) {
+		otherFirmID := "firm-dst-bogota"
+		madridLawyerID := "lawyer-dst-madrid"
+		db.Create(&models.Firm{ID: otherFirmID, Timezone: "America/Bogota"})
+		db.Create(&models.User{ID: madridLawyerID, Email: "madrid@dst.test", FirmID: &otherFirmID})
+		assert.NoError(t, CreateAvailabilitySlot(db, &models.Availability{
+			LawyerID: madridLawyerID, DayOfWeek: 1, StartTime: "09:00", EndTime: "10:00", Timezone: "Europe/Madrid", IsActive: true,
+		}))
+
+		// Madrid leaves summer time on October 25th 2026
+		before := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+		after := time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC)
+		for _, start := range []time.Time{before, after} {
+			available, err := IsTimeSlotAvailable(db, madridLawyerID, start, start.Add(time.Hour))
+			assert.NoError(t, err)
+			assert.True(t, available, start.String())
+		}
+
+		slots, err := GetAvailableSlots(db, madridLawyerID, time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC), 60, "America/Bogota")
+		assert.NoError(t, err)
+		if assert.Len(t, slots, 1) {
+			assert.Equal(t, after, slots[0].StartTime)
+		}
+	})
+
+	t.Run("Slots saved without a timezone fall back to the firm timezone", func(t *testing.T) {
+		legacyLawyerID := "lawyer-dst-legacy"
+		db.Create(&models.User{ID: legacyLawyerID, Email: "legacy@dst.test", FirmID: &firmID})
+		db.Create(&models.Availability{LawyerID: legacyLawyerID, DayOfWeek: 1, StartTime: "09:00", EndTime: "10:00", IsActive: true})
+
+		start := time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC)
+		available, err := IsTimeSlotAvailable(db, legacyLawyerID, start, start.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.True(t, available)
+	})
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"time"
+)
+
+// Holidays returns the public holidays of a country (ISO 3166-1 alpha-3) for a year,
+// keyed by "2006-01-02". Countries without a calendar only skip weekends.
+func Holidays(countryCode string, year int) map[string]bool {
+	switch countryCode {
+	case "COL":
+		return colombianHolidays(year)
+	default:
+		return map[string]bool{}
+	}
+}
+
+// IsBusinessDay returns true if the date is neither a weekend nor a public holiday
+func IsBusinessDay(date time.Time, countryCode string) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return !Holidays(countryCode, date.Year())[date.Format("2006-01-02")]
+}
+
+// SubtractBusinessDays counts back the given number of business days from date.
+// The starting date itself is never counted.
+func SubtractBusinessDays(date time.Time, days int, countryCode string) time.Time {
+	result := date
+	for days > 0 {
+		result = result.AddDate(0, 0, -1)
+		if IsBusinessDay(result, countryCode) {
+			days--
+		}
+	}
+	return result
+}
+
+// PreviousBusinessDay returns date if it is a business day, otherwise the closest business day before it
+func PreviousBusinessDay(date time.Time, countryCode string) time.Time {
+	for !IsBusinessDay(date, countryCode) {
+		date = date.AddDate(0, 0, -1)
+	}
+	return date
+}
+
+// colombianHolidays builds the Colombian holiday calendar (Ley 51 de 1983, "Ley Emiliani").
+// Most religious and civic holidays are moved to the following Monday.
+func colombianHolidays(year int) map[string]bool {
+	holidays := map[string]bool{}
+	add := func(t time.Time) { holidays[t.Format("2006-01-02")] = true }
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	// Fixed holidays
+	add(date(time.January, 1))
+	add(date(time.May, 1))
+	add(date(time.July, 20))
+	add(date(time.August, 7))
+	add(date(time.December, 8))
+	add(date(time.December, 25))
+
+	// Holidays moved to the next Monday
+	for _, d := range []time.Time{
+		date(time.January, 6),
+		date(time.March, 19),
+		date(time.June, 29),
+		date(time.August, 15),
+		date(time.October, 12),
+		date(time.November, 1),
+		date(time.November, 11),
+	} {
+		add(nextMonday(d))
+	}
+
+	// Easter-based holidays
+	easter := easterSunday(year)
+	add(easter.AddDate(0, 0, -3))             // Holy Thursday
+	add(easter.AddDate(0, 0, -2))             // Good Friday
+	add(nextMonday(easter.AddDate(0, 0, 39))) // Ascension
+	add(nextMonday(easter.AddDate(0, 0, 60))) // Corpus Christi
+	add(nextMonday(easter.AddDate(0, 0, 68))) // Sacred Heart
+
+	return holidays
+}
+
+// nextMonday returns date if it is a Monday, otherwise the following Monday
+func nextMonday(date time.Time) time.Time {
+	offset := (int(time.Monday) - int(date.Weekday()) + 7) % 7
+	return date.AddDate(0, 0, offset)
+}
+
+// easterSunday computes Easter Sunday for the Gregorian calendar (anonymous algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
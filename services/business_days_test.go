@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEasterSunday(t *testing.T) {
+	assert.Equal(t, day(2024, time.March, 31), easterSunday(2024))
+	assert.Equal(t, day(2025, time.April, 20), easterSunday(2025))
+	assert.Equal(t, day(2026, time.April, 5), easterSunday(2026))
+}
+
+func TestColombianHolidays(t *testing.T) {
+	holidays := Holidays("COL", 2025)
+
+	expected := []string{
+		"2025-01-01", "2025-01-06", "2025-03-24", "2025-04-17", "2025-04-18",
+		"2025-05-01", "2025-06-02", "2025-06-23", "2025-06-30", "2025-07-20",
+		"2025-08-07", "2025-08-18", "2025-10-13", "2025-11-03", "2025-11-17",
+		"2025-12-08", "2025-12-25",
+	}
+	for _, date := range expected {
+		assert.True(t, holidays[date], "expected %s to be a holiday", date)
+	}
+	assert.Len(t, holidays, len(expected))
+
+	t.Run("Unknown country has no holidays", func(t *testing.T) {
+		assert.Empty(t, Holidays("USA", 2025))
+	})
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	assert.True(t, IsBusinessDay(day(2025, time.March, 21), "COL"))  // Friday
+	assert.False(t, IsBusinessDay(day(2025, time.March, 22), "COL")) // Saturday
+	assert.False(t, IsBusinessDay(day(2025, time.March, 23), "COL")) // Sunday
+	assert.False(t, IsBusinessDay(day(2025, time.March, 24), "COL")) // San José (moved)
+	assert.True(t, IsBusinessDay(day(2025, time.March, 24), ""))     // No calendar
+	assert.True(t, IsBusinessDay(day(2025, time.March, 19), "COL"))  // Original San José date
+	assert.False(t, IsBusinessDay(day(2025, time.December, 25), "COL"))
+}
+
+func TestSubtractBusinessDays(t *testing.T) {
+	t.Run("Skips weekend and holiday", func(t *testing.T) {
+		assert.Equal(t, day(2025, time.March, 21), SubtractBusinessDays(day(2025, time.March, 25), 1, "COL"))
+		assert.Equal(t, day(2025, time.March, 24), SubtractBusinessDays(day(2025, time.March, 25), 1, ""))
+	})
+
+	t.Run("Skips Holy Week", func(t *testing.T) {
+		assert.Equal(t, day(2025, time.April, 14), SubtractBusinessDays(day(2025, time.April, 21), 3, "COL"))
+	})
+
+	t.Run("Crosses year boundary", func(t *testing.T) {
+		// Jan 1 is a holiday
+		assert.Equal(t, day(2025, time.December, 30), SubtractBusinessDays(day(2026, time.January, 2), 2, "COL"))
+	})
+
+	t.Run("Zero days returns the same date", func(t *testing.T) {
+		assert.Equal(t, day(2025, time.March, 25), SubtractBusinessDays(day(2025, time.March, 25), 0, "COL"))
+	})
+}
+
+func TestPreviousBusinessDay(t *testing.T) {
+	assert.Equal(t, day(2025, time.March, 21), PreviousBusinessDay(day(2025, time.March, 24), "COL"))
+	assert.Equal(t, day(2025, time.March, 25), PreviousBusinessDay(day(2025, time.March, 25), "COL"))
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CaseApproval-related errors
+var (
+	ErrCaseApprovalNotFound   = errors.New("case approval not found")
+	ErrCaseApprovalNotPending = errors.New("case approval has already been decided")
+	ErrCaseApprovalSelf       = errors.New("the requester cannot approve their own case")
+	ErrCaseApprovalForbidden  = errors.New("only admins can decide case approvals")
+)
+
+// IntakeApprovalResult holds the reasons a new case needs approval
+type IntakeApprovalResult struct {
+	Reasons         []string
+	ConflictDetails string
+}
+
+// Required returns true if at least one approval criterion matched
+func (r *IntakeApprovalResult) Required() bool {
+	return len(r.Reasons) > 0
+}
+
+// FindClientConflicts returns the firm's cases where the client appears as the opposing party.
+// Parties are matched by document number when both have one, otherwise by name.
+func FindClientConflicts(db *gorm.DB, firmID, clientID string) ([]models.CaseParty, error) {
+	var client models.User
+	if err := db.First(&client, "id = ? AND firm_id = ?", clientID, firmID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	query := db.Joins("JOIN cases ON cases.id = case_parties.case_id").
+		Where("cases.firm_id = ? AND cases.client_id <> ? AND cases.deleted_at IS NULL", firmID, clientID).
+		Preload("Case")
+
+	if client.DocumentNumber != nil && strings.TrimSpace(*client.DocumentNumber) != "" {
+		query = query.Where(
+			db.Where("case_parties.document_number = ?", strings.TrimSpace(*client.DocumentNumber)).
+				Or("(case_parties.document_number IS NULL OR case_parties.document_number = '') AND LOWER(case_parties.name) = ?", strings.ToLower(strings.TrimSpace(client.Name))),
+		)
+	} else {
+		query = query.Where("LOWER(case_parties.name) = ?", strings.ToLower(strings.TrimSpace(client.Name)))
+	}
+
+	var parties []models.CaseParty
+	err := query.Find(&parties).Error
+	return parties, err
+}
+
+// EvaluateIntakeApproval checks a new case against the firm's intake approval criteria
+func EvaluateIntakeApproval(db *gorm.DB, firm *models.Firm, caseRecord *models.Case) (*IntakeApprovalResult, error) {
+	result := &IntakeApprovalResult{}
+	if firm == nil || !firm.IntakeApprovalEnabled {
+		return result, nil
+	}
+
+	if firm.IntakeApprovalAmountThreshold != nil && caseRecord.AmountInDispute != nil &&
+		*caseRecord.AmountInDispute >= *firm.IntakeApprovalAmountThreshold {
+		result.Reasons = append(result.Reasons, models.CaseApprovalReasonAmount)
+	}
+
+	if caseRecord.BranchID != nil && firm.IsIntakeApprovalBranch(*caseRecord.BranchID) {
+		result.Reasons = append(result.Reasons, models.CaseApprovalReasonBranch)
+	}
+
+	if firm.IntakeApprovalOnConflict {
+		conflicts, err := FindClientConflicts(db, firm.ID, caseRecord.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		if len(conflicts) > 0 {
+			result.Reasons = append(result.Reasons, models.CaseApprovalReasonConflict)
+			var details []string
+			for _, party := range conflicts {
+				details = append(details, fmt.Sprintf("%s (%s)", party.Case.CaseNumber, party.Name))
+			}
+			result.ConflictDetails = strings.Join(details, ", ")
+		}
+	}
+
+	return result, nil
+}
+
+// HasCaseApprover reports whether an active admin other than the requester exists to decide
+// the approval. Without one a pending case could never be opened, so intake skips approval.
+func HasCaseApprover(db *gorm.DB, firmID, requestedByID string) (bool, error) {
+	var count int64
+	err := db.Model(&models.User{}).
+		Where("firm_id = ? AND role = ? AND is_active = ? AND id <> ?", firmID, "admin", true, requestedByID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// RequestCaseApproval records a pending approval for a case and notifies the firm's admins
+func RequestCaseApproval(db *gorm.DB, caseRecord *models.Case, requestedByID string, result *IntakeApprovalResult) (*models.CaseApproval, error) {
+	approval := &models.CaseApproval{
+		FirmID:        caseRecord.FirmID,
+		CaseID:        caseRecord.ID,
+		RequestedByID: requestedByID,
+		Reasons:       strings.Join(result.Reasons, ","),
+		Status:        models.CaseApprovalStatusPending,
+	}
+	if result.ConflictDetails != "" {
+		approval.ConflictDetails = &result.ConflictDetails
+	}
+	if err := db.Create(approval).Error; err != nil {
+		return nil, err
+	}
+
+	// Notify every other active admin; the requester cannot approve
+	var admins []models.User
+	if err := db.Where("firm_id = ? AND role = ? AND is_active = ? AND id <> ?", caseRecord.FirmID, "admin", true, requestedByID).
+		Find(&admins).Error; err != nil {
+		return nil, err
+	}
+	for _, admin := range admins {
+		adminID := admin.ID
+		caseID := caseRecord.ID
+		lang := approvalNotificationLang(&admin)
+		reasons := make([]string, 0, len(result.Reasons))
+		for _, reason := range result.Reasons {
+			reasons = append(reasons, i18n.Translate(lang, "cases.approval.reasons."+strings.ToLower(reason)))
+		}
+		args := map[string]interface{}{"case": caseRecord.CaseNumber, "reasons": strings.Join(reasons, ", ")}
+		notification := &models.Notification{
+			FirmID:  caseRecord.FirmID,
+			UserID:  &adminID,
+			CaseID:  &caseID,
+			Type:    models.NotificationTypeApprovalRequest,
+			Title:   i18n.Translate(lang, "cases.approval.notification.request_title", args),
+			Message: i18n.Translate(lang, "cases.approval.notification.request_message", args),
+			LinkURL: "/cases/" + caseRecord.ID,
+		}
+		if err := db.Create(notification).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return approval, nil
+}
+
+// GetPendingCaseApproval retrieves the pending approval for a case
+func GetPendingCaseApproval(db *gorm.DB, firmID, caseID string) (*models.CaseApproval, error) {
+	var approval models.CaseApproval
+	err := db.Preload("RequestedBy").
+		Where("firm_id = ? AND case_id = ? AND status = ?", firmID, caseID, models.CaseApprovalStatusPending).
+		Order("created_at DESC").
+		First(&approval).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCaseApprovalNotFound
+		}
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// DecideCaseApproval approves or rejects a pending case. Approved cases are opened;
+// rejected cases are closed. The requester is notified of the decision.
+func DecideCaseApproval(db *gorm.DB, approval *models.CaseApproval, approver *models.User, approve bool, notes string) error {
+	if !approval.IsPending() {
+		return ErrCaseApprovalNotPending
+	}
+	if approver.Role != "admin" {
+		return ErrCaseApprovalForbidden
+	}
+	if approver.ID == approval.RequestedByID {
+		return ErrCaseApprovalSelf
+	}
+
+	now := time.Now()
+	approvalStatus := models.CaseApprovalStatusApproved
+	caseUpdates := map[string]interface{}{
+		"status":            models.CaseStatusOpen,
+		"status_changed_at": now,
+		"status_changed_by": approver.ID,
+	}
+	if !approve {
+		approvalStatus = models.CaseApprovalStatusRejected
+		caseUpdates["status"] = models.CaseStatusClosed
+		caseUpdates["closed_at"] = now
+		caseUpdates["is_historical"] = true
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		approvalUpdates := map[string]interface{}{
+			"status":        approvalStatus,
+			"decided_by_id": approver.ID,
+			"decided_at":    now,
+		}
+		if notes != "" {
+			approvalUpdates["decision_notes"] = notes
+		}
+		// Only a still-pending approval is decided, so two admins deciding at once can't both apply
+		result := tx.Model(&models.CaseApproval{}).
+			Where("id = ? AND status = ?", approval.ID, models.CaseApprovalStatusPending).
+			Updates(approvalUpdates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCaseApprovalNotPending
+		}
+		approval.Status = approvalStatus
+		approval.DecidedByID = &approver.ID
+		approval.DecidedAt = &now
+		if notes != "" {
+			approval.DecisionNotes = &notes
+		}
+
+		var caseRecord models.Case
+		if err := tx.First(&caseRecord, "id = ? AND firm_id = ?", approval.CaseID, approval.FirmID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&caseRecord).Updates(caseUpdates).Error; err != nil {
+			return err
+		}
+
+		var requester models.User
+		if err := tx.Select("id", "language").First(&requester, "id = ?", approval.RequestedByID).Error; err != nil {
+			return err
+		}
+		lang := approvalNotificationLang(&requester)
+		titleKey := "cases.approval.notification.approved_title"
+		if !approve {
+			titleKey = "cases.approval.notification.rejected_title"
+		}
+		requesterID := requester.ID
+		caseID := caseRecord.ID
+		return tx.Create(&models.Notification{
+			FirmID:  approval.FirmID,
+			UserID:  &requesterID,
+			CaseID:  &caseID,
+			Type:    models.NotificationTypeApprovalRequest,
+			Title:   i18n.Translate(lang, titleKey, map[string]interface{}{"case": caseRecord.CaseNumber}),
+			Message: notes,
+			LinkURL: "/cases/" + caseRecord.ID,
+		}).Error
+	})
+}
+
+// approvalNotificationLang returns the language approval notifications are written in for a user
+func approvalNotificationLang(user *models.User) string {
+	if user.Language == "" {
+		return "es"
+	}
+	return user.Language
+}
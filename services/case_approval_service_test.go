@@ -0,0 +1,191 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupCaseApprovalTestDB(t *testing.T) (*gorm.DB, *models.Firm, *models.User, *models.User) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Firm{}, &models.User{}, &models.Case{}, &models.CaseParty{}, &models.CaseApproval{}, &models.Notification{}))
+
+	threshold := 100000.0
+	firm := &models.Firm{
+		Name: "Test Firm", BillingEmail: "billing@test.com",
+		IntakeApprovalEnabled: true, IntakeApprovalAmountThreshold: &threshold,
+		IntakeApprovalBranchIDs: "branch-risky", IntakeApprovalOnConflict: true,
+	}
+	db.Create(firm)
+	partner := &models.User{FirmID: &firm.ID, Name: "Partner", Email: "partner@test.com", Role: "admin", IsActive: true}
+	db.Create(partner)
+	client := &models.User{FirmID: &firm.ID, Name: "Acme Corp", Email: "acme@test.com", Role: "client", IsActive: true}
+	db.Create(client)
+
+	return db, firm, partner, client
+}
+
+func createApprovalTestCase(t *testing.T, db *gorm.DB, firm *models.Firm, clientID, number string) *models.Case {
+	caseRecord := &models.Case{
+		FirmID: firm.ID, ClientID: clientID, CaseNumber: number, CaseType: "Civil",
+		Description: "Test", Status: models.CaseStatusPendingApproval, OpenedAt: time.Now(),
+	}
+	require.NoError(t, db.Create(caseRecord).Error)
+	return caseRecord
+}
+
+func TestEvaluateIntakeApproval(t *testing.T) {
+	db, firm, _, client := setupCaseApprovalTestDB(t)
+
+	small := 5000.0
+	large := 250000.0
+	risky := "branch-risky"
+	safe := "branch-safe"
+
+	result, err := EvaluateIntakeApproval(db, firm, &models.Case{ClientID: client.ID, AmountInDispute: &small, BranchID: &safe})
+	require.NoError(t, err)
+	assert.False(t, result.Required())
+
+	result, err = EvaluateIntakeApproval(db, firm, &models.Case{ClientID: client.ID, AmountInDispute: &large, BranchID: &risky})
+	require.NoError(t, err)
+	assert.Equal(t, []string{models.CaseApprovalReasonAmount, models.CaseApprovalReasonBranch}, result.Reasons)
+
+	// Disabled policies never require approval
+	firm.IntakeApprovalEnabled = false
+	result, err = EvaluateIntakeApproval(db, firm, &models.Case{ClientID: client.ID, AmountInDispute: &large})
+	require.NoError(t, err)
+	assert.False(t, result.Required())
+}
+
+func TestEvaluateIntakeApprovalConflict(t *testing.T) {
+	db, firm, _, client := setupCaseApprovalTestDB(t)
+
+	other := &models.User{FirmID: &firm.ID, Name: "Other Client", Email: "other@test.com", Role: "client", IsActive: true}
+	db.Create(other)
+	existing := createApprovalTestCase(t, db, firm, other.ID, "CASE-001")
+	db.Create(&models.CaseParty{CaseID: existing.ID, PartyType: "DEMANDADO", Name: "ACME Corp"})
+
+	result, err := EvaluateIntakeApproval(db, firm, &models.Case{ClientID: client.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{models.CaseApprovalReasonConflict}, result.Reasons)
+	assert.Contains(t, result.ConflictDetails, "CASE-001")
+
+	// Turning off the conflict criterion skips the check
+	firm.IntakeApprovalOnConflict = false
+	result, err = EvaluateIntakeApproval(db, firm, &models.Case{ClientID: client.ID})
+	require.NoError(t, err)
+	assert.False(t, result.Required())
+}
+
+func TestDecideCaseApproval(t *testing.T) {
+	db, firm, partner, client := setupCaseApprovalTestDB(t)
+
+	second := &models.User{FirmID: &firm.ID, Name: "Second Partner", Email: "second@test.com", Role: "admin", IsActive: true}
+	db.Create(second)
+	lawyer := &models.User{FirmID: &firm.ID, Name: "Lawyer", Email: "lawyer@test.com", Role: "lawyer", IsActive: true, Language: "en"}
+	db.Create(lawyer)
+	i18n.Load()
+
+	t.Run("requester cannot approve", func(t *testing.T) {
+		caseRecord := createApprovalTestCase(t, db, firm, client.ID, "CASE-SELF")
+		approval, err := RequestCaseApproval(db, caseRecord, partner.ID, &IntakeApprovalResult{Reasons: []string{models.CaseApprovalReasonAmount}})
+		require.NoError(t, err)
+
+		// Only the other admin is notified
+		var notifications []models.Notification
+		db.Where("case_id = ?", caseRecord.ID).Find(&notifications)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, second.ID, *notifications[0].UserID)
+		// Written in the admin's language, which defaults to Spanish
+		assert.Equal(t, "Aprobación requerida: CASE-SELF", notifications[0].Title)
+		assert.Contains(t, notifications[0].Message, "La cuantía supera el umbral de la firma")
+
+		assert.ErrorIs(t, DecideCaseApproval(db, approval, partner, true, ""), ErrCaseApprovalSelf)
+		assert.ErrorIs(t, DecideCaseApproval(db, approval, lawyer, true, ""), ErrCaseApprovalForbidden)
+	})
+
+	t.Run("approve opens the case", func(t *testing.T) {
+		caseRecord := createApprovalTestCase(t, db, firm, client.ID, "CASE-APPROVE")
+		approval, err := RequestCaseApproval(db, caseRecord, lawyer.ID, &IntakeApprovalResult{Reasons: []string{models.CaseApprovalReasonBranch}})
+		require.NoError(t, err)
+
+		require.NoError(t, DecideCaseApproval(db, approval, partner, true, ""))
+
+		var updated models.Case
+		db.First(&updated, "id = ?", caseRecord.ID)
+		assert.Equal(t, models.CaseStatusOpen, updated.Status)
+
+		var stored models.CaseApproval
+		db.First(&stored, "id = ?", approval.ID)
+		assert.Equal(t, models.CaseApprovalStatusApproved, stored.Status)
+		assert.Equal(t, partner.ID, *stored.DecidedByID)
+
+		assert.ErrorIs(t, DecideCaseApproval(db, &stored, second, true, ""), ErrCaseApprovalNotPending)
+	})
+
+	t.Run("concurrent decisions apply once", func(t *testing.T) {
+		caseRecord := createApprovalTestCase(t, db, firm, client.ID, "CASE-RACE")
+		approval, err := RequestCaseApproval(db, caseRecord, lawyer.ID, &IntakeApprovalResult{Reasons: []string{models.CaseApprovalReasonAmount}})
+		require.NoError(t, err)
+
+		// Both admins loaded the approval while it was still pending
+		stale := *approval
+		require.NoError(t, DecideCaseApproval(db, approval, partner, true, ""))
+		assert.ErrorIs(t, DecideCaseApproval(db, &stale, second, false, "Too late"), ErrCaseApprovalNotPending)
+
+		var updated models.Case
+		db.First(&updated, "id = ?", caseRecord.ID)
+		assert.Equal(t, models.CaseStatusOpen, updated.Status)
+	})
+
+	t.Run("reject closes the case", func(t *testing.T) {
+		caseRecord := createApprovalTestCase(t, db, firm, client.ID, "CASE-REJECT")
+		approval, err := RequestCaseApproval(db, caseRecord, lawyer.ID, &IntakeApprovalResult{Reasons: []string{models.CaseApprovalReasonConflict}})
+		require.NoError(t, err)
+
+		require.NoError(t, DecideCaseApproval(db, approval, second, false, "Conflict of interest"))
+
+		var updated models.Case
+		db.First(&updated, "id = ?", caseRecord.ID)
+		assert.Equal(t, models.CaseStatusClosed, updated.Status)
+		assert.NotNil(t, updated.ClosedAt)
+
+		// The requester is told in their own language
+		var decision models.Notification
+		require.NoError(t, db.First(&decision, "case_id = ? AND user_id = ?", caseRecord.ID, lawyer.ID).Error)
+		assert.Equal(t, "Case rejected: CASE-REJECT", decision.Title)
+		assert.Equal(t, "Conflict of interest", decision.Message)
+
+		pending, err := GetPendingCaseApproval(db, firm.ID, caseRecord.ID)
+		assert.Nil(t, pending)
+		assert.ErrorIs(t, err, ErrCaseApprovalNotFound)
+	})
+}
+
+func TestHasCaseApprover(t *testing.T) {
+	db, firm, partner, _ := setupCaseApprovalTestDB(t)
+	lawyer := &models.User{FirmID: &firm.ID, Name: "Lawyer", Email: "lawyer@test.com", Role: "lawyer", IsActive: true}
+	db.Create(lawyer)
+
+	// The only admin cannot approve their own intake
+	ok, err := HasCaseApprover(db, firm.ID, partner.ID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = HasCaseApprover(db, firm.ID, lawyer.ID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// An inactive admin does not count
+	db.Model(partner).Update("is_active", false)
+	ok, err = HasCaseApprover(db, firm.ID, lawyer.ID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -34,6 +34,20 @@ func GetCaseBranches(db *gorm.DB, firmID string, domainID string) ([]models.Case
 	return branches, err
 }
 
+// GetAllCaseBranches fetches every active case branch of a firm with its domain
+func GetAllCaseBranches(db *gorm.DB, firmID string) ([]models.CaseBranch, error) {
+	var branches []models.CaseBranch
+
+	err := db.
+		Preload("Domain").
+		Where("firm_id = ?", firmID).
+		Where("is_active = ?", true).
+		Order("domain_id ASC, `order` ASC, name ASC").
+		Find(&branches).Error
+
+	return branches, err
+}
+
 // GetCaseSubtypes fetches active case subtypes for a branch
 func GetCaseSubtypes(db *gorm.DB, firmID string, branchID string) ([]models.CaseSubtype, error) {
 	var subtypes []models.CaseSubtype
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"law_flow_app_go/config"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContractReminder-related errors
+var (
+	ErrContractReminderNotFound = errors.New("contract reminder not found")
+	ErrInvalidNoticeDays        = errors.New("notice business days cannot be negative")
+)
+
+// ComputeNoticeDeadline returns the last day a termination notice can be sent so that
+// the required business days of notice are given before the renewal date
+func ComputeNoticeDeadline(renewalDate time.Time, noticeBusinessDays int, countryCode string) time.Time {
+	if noticeBusinessDays <= 0 {
+		return renewalDate
+	}
+	return SubtractBusinessDays(renewalDate, noticeBusinessDays, countryCode)
+}
+
+// firmCountryCode returns the ISO alpha-3 country code of a firm, used for its holiday calendar
+func firmCountryCode(db *gorm.DB, firmID string) string {
+	var firm models.Firm
+	if err := db.Preload("Country").First(&firm, "id = ?", firmID).Error; err != nil || firm.Country == nil {
+		return ""
+	}
+	return firm.Country.Code
+}
+
+// SetContractNoticeDeadline validates the renewal terms and recomputes the notice deadline
+// using the firm's country holidays
+func SetContractNoticeDeadline(db *gorm.DB, reminder *models.ContractReminder) error {
+	if reminder.NoticeBusinessDays < 0 {
+		return ErrInvalidNoticeDays
+	}
+	reminder.NoticeDeadline = ComputeNoticeDeadline(reminder.RenewalDate, reminder.NoticeBusinessDays, firmCountryCode(db, reminder.FirmID))
+	return nil
+}
+
+// GetContractReminderByID retrieves a contract reminder scoped to a firm
+func GetContractReminderByID(db *gorm.DB, firmID, reminderID string) (*models.ContractReminder, error) {
+	var reminder models.ContractReminder
+	err := db.Preload("Client").
+		Preload("ResponsibleLawyer").
+		Preload("Case").
+		Where("firm_id = ? AND id = ?", firmID, reminderID).
+		First(&reminder).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrContractReminderNotFound
+		}
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// ScopeContractRemindersForUser restricts a contract reminder query to what the user can see:
+// clients see their own contracts, lawyers the ones they are responsible for, and roles
+// without contract access none
+func ScopeContractRemindersForUser(query *gorm.DB, user *models.User) *gorm.DB {
+	if !user.Can(models.CapabilityContractsView) {
+		return query.Where("1 = 0")
+	}
+	switch user.Role {
+	case "client":
+		return query.Where("client_id = ?", user.ID)
+	case "lawyer":
+		return query.Where("responsible_lawyer_id = ?", user.ID)
+	default:
+		return query
+	}
+}
+
+// GetUpcomingRenewals returns active contracts renewing from today on, soonest notice deadline first
+func GetUpcomingRenewals(db *gorm.DB, firmID string, user *models.User, limit int) ([]models.ContractReminder, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	query := db.Model(&models.ContractReminder{}).
+		Where("firm_id = ? AND status = ? AND renewal_date >= ?", firmID, models.ContractStatusActive, today)
+	query = ScopeContractRemindersForUser(query, user)
+
+	var reminders []models.ContractReminder
+	err := query.Preload("Client").
+		Preload("ResponsibleLawyer").
+		Order("notice_deadline ASC").
+		Limit(limit).
+		Find(&reminders).Error
+	return reminders, err
+}
+
+// RollOverAutoRenewals moves auto-renewing contracts whose renewal date passed to their
+// next term, so alerts are sent again ahead of the following renewal
+func RollOverAutoRenewals(db *gorm.DB, now time.Time) error {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var reminders []models.ContractReminder
+	if err := db.Where("status = ? AND auto_renews = ? AND renewal_term_months > 0 AND renewal_date < ?",
+		models.ContractStatusActive, true, today).
+		Find(&reminders).Error; err != nil {
+		return err
+	}
+
+	for i := range reminders {
+		reminder := &reminders[i]
+		for reminder.RenewalDate.Before(today) {
+			reminder.RenewalDate = reminder.RenewalDate.AddDate(0, reminder.RenewalTermMonths, 0)
+		}
+		if err := SetContractNoticeDeadline(db, reminder); err != nil {
+			log.Printf("Error computing notice deadline for contract %s: %v", reminder.ID, err)
+			continue
+		}
+		if err := db.Model(reminder).UpdateColumns(map[string]interface{}{
+			"renewal_date":    reminder.RenewalDate,
+			"notice_deadline": reminder.NoticeDeadline,
+			"alert_sent_at":   nil,
+		}).Error; err != nil {
+			log.Printf("Error rolling over contract %s: %v", reminder.ID, err)
+		}
+	}
+	return nil
+}
+
+// GetDueContractAlerts returns active contracts whose alert window has opened and
+// that have not been alerted yet
+func GetDueContractAlerts(db *gorm.DB, now time.Time) ([]models.ContractReminder, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var candidates []models.ContractReminder
+	if err := db.Preload("Client").
+		Preload("ResponsibleLawyer").
+		Preload("Firm").
+		Where("status = ? AND alert_sent_at IS NULL AND renewal_date >= ?", models.ContractStatusActive, today).
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	var due []models.ContractReminder
+	for _, reminder := range candidates {
+		if !reminder.AlertDate().After(today) {
+			due = append(due, reminder)
+		}
+	}
+	return due, nil
+}
+
+// SendContractRenewalAlerts notifies the responsible lawyer (and the client, if enabled)
+// about contracts whose termination notice deadline is approaching.
+// This should be run as a scheduled job
+func SendContractRenewalAlerts(db *gorm.DB) error {
+	now := time.Now()
+	if err := RollOverAutoRenewals(db, now); err != nil {
+		log.Printf("Error rolling over auto-renewing contracts: %v", err)
+	}
+
+	reminders, err := GetDueContractAlerts(db, now)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.Load()
+	notificationService := NewNotificationService(db)
+
+	for i := range reminders {
+		reminder := &reminders[i]
+
+		recipients := []*models.User{reminder.ResponsibleLawyer}
+		if reminder.NotifyClient {
+			recipients = append(recipients, reminder.Client)
+		}
+
+		for _, recipient := range recipients {
+			if recipient == nil || !recipient.IsActive {
+				continue
+			}
+			lang := recipient.Language
+			if lang == "" {
+				lang = "es"
+			}
+			args := map[string]interface{}{
+				"title":    reminder.Title,
+				"deadline": reminder.NoticeDeadline.Format("2006-01-02"),
+				"renewal":  reminder.RenewalDate.Format("2006-01-02"),
+			}
+
+			userID := recipient.ID
+			notification := &models.Notification{
+				FirmID:  reminder.FirmID,
+				UserID:  &userID,
+				CaseID:  reminder.CaseID,
+				Type:    models.NotificationTypeContractRenewal,
+				Title:   i18n.Translate(lang, "contracts.alert.title", args),
+				Message: i18n.Translate(lang, "contracts.alert.message", args),
+				LinkURL: "/contracts",
+			}
+			if err := notificationService.CreateNotification(notification); err != nil {
+				log.Printf("Error creating contract renewal notification for %s: %v", reminder.ID, err)
+			}
+
+			email := BuildContractRenewalReminderEmail(recipient.Email, contractRenewalEmailData(reminder, recipient, cfg.AppURL), lang)
+			if recipient.Role == "client" {
+				SendLoggedEmailAsync(db, cfg, email, EmailLogEntry{
+					FirmID:      reminder.FirmID,
+					RecipientID: &userID,
+					CaseID:      reminder.CaseID,
+					Category:    models.EmailCategoryContractRenewal,
+				})
+			} else {
+				SendEmailAsync(cfg, email)
+			}
+		}
+
+		if err := db.Model(reminder).UpdateColumn("alert_sent_at", now).Error; err != nil {
+			log.Printf("Error marking contract %s as alerted: %v", reminder.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// contractRenewalEmailData builds the email template data for a contract alert recipient
+func contractRenewalEmailData(reminder *models.ContractReminder, recipient *models.User, appURL string) ContractRenewalReminderEmailData {
+	data := ContractRenewalReminderEmailData{
+		RecipientName:  recipient.Name,
+		ContractTitle:  reminder.Title,
+		RenewalDate:    reminder.RenewalDate.Format("2006-01-02"),
+		NoticeDeadline: reminder.NoticeDeadline.Format("2006-01-02"),
+		NoticeDays:     reminder.NoticeBusinessDays,
+		AutoRenews:     reminder.AutoRenews,
+		Link:           fmt.Sprintf("%s/contracts", appURL),
+	}
+	if reminder.Firm != nil {
+		data.FirmName = reminder.Firm.Name
+	}
+	if reminder.Client != nil {
+		data.ClientName = reminder.Client.Name
+	}
+	if reminder.Counterparty != nil {
+		data.Counterparty = *reminder.Counterparty
+	}
+	return data
+}
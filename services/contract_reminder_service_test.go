@@ -0,0 +1,158 @@
+package services
+
+import (
+	"fmt"
+	"law_flow_app_go/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupContractReminderTestDB(t *testing.T) (*gorm.DB, *models.Firm, *models.User, *models.User) {
+	// Shared cache so the async email goroutine sees the same database
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_pragma=busy_timeout(5000)", uuid.New().String())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Country{}, &models.Firm{}, &models.User{}, &models.Case{}, &models.ContractReminder{}, &models.Notification{}, &models.EmailLog{}))
+
+	country := &models.Country{Code: "COL", Name: "Colombia", IsActive: true}
+	db.Create(country)
+	firm := &models.Firm{Name: "Test Firm", CountryID: country.ID, BillingEmail: "billing@test.com", NoreplyEmail: "noreply@test.com", EmailSenderName: "Test"}
+	db.Create(firm)
+	lawyer := &models.User{FirmID: &firm.ID, Name: "Lawyer", Email: "lawyer@test.com", Role: "lawyer", IsActive: true, Language: "en"}
+	db.Create(lawyer)
+	client := &models.User{FirmID: &firm.ID, Name: "Client", Email: "client@test.com", Role: "client", IsActive: true, Language: "es"}
+	db.Create(client)
+
+	return db, firm, lawyer, client
+}
+
+func TestComputeNoticeDeadline(t *testing.T) {
+	// Renewal on Tuesday after the San José holiday Monday
+	renewal := day(2025, time.March, 25)
+
+	assert.Equal(t, renewal, ComputeNoticeDeadline(renewal, 0, "COL"))
+	assert.Equal(t, day(2025, time.March, 21), ComputeNoticeDeadline(renewal, 1, "COL"))
+	assert.Equal(t, day(2025, time.March, 11), ComputeNoticeDeadline(renewal, 9, "COL"))
+}
+
+func TestSetContractNoticeDeadline(t *testing.T) {
+	db, firm, lawyer, client := setupContractReminderTestDB(t)
+
+	reminder := &models.ContractReminder{
+		FirmID: firm.ID, ClientID: client.ID, ResponsibleLawyerID: lawyer.ID,
+		Title: "Supply Agreement", RenewalDate: day(2025, time.March, 25), NoticeBusinessDays: 1,
+	}
+	assert.NoError(t, SetContractNoticeDeadline(db, reminder))
+	assert.Equal(t, day(2025, time.March, 21), reminder.NoticeDeadline)
+
+	reminder.NoticeBusinessDays = -1
+	assert.ErrorIs(t, SetContractNoticeDeadline(db, reminder), ErrInvalidNoticeDays)
+}
+
+func TestContractRenewalAlerts(t *testing.T) {
+	db, firm, lawyer, client := setupContractReminderTestDB(t)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	due := models.ContractReminder{
+		FirmID: firm.ID, ClientID: client.ID, ResponsibleLawyerID: lawyer.ID, Title: "Due",
+		RenewalDate: today.AddDate(0, 0, 20), NoticeDeadline: today.AddDate(0, 0, 10),
+		AlertDaysBefore: 15, NotifyClient: true, Status: models.ContractStatusActive,
+	}
+	notYet := models.ContractReminder{
+		FirmID: firm.ID, ClientID: client.ID, ResponsibleLawyerID: lawyer.ID, Title: "Not yet",
+		RenewalDate: today.AddDate(0, 0, 90), NoticeDeadline: today.AddDate(0, 0, 60),
+		AlertDaysBefore: 30, Status: models.ContractStatusActive,
+	}
+	terminated := models.ContractReminder{
+		FirmID: firm.ID, ClientID: client.ID, ResponsibleLawyerID: lawyer.ID, Title: "Terminated",
+		RenewalDate: today.AddDate(0, 0, 5), NoticeDeadline: today.AddDate(0, 0, 1),
+		AlertDaysBefore: 30, Status: models.ContractStatusTerminated,
+	}
+	db.Create(&due)
+	db.Create(&notYet)
+	db.Create(&terminated)
+
+	t.Run("Only contracts inside the alert window are due", func(t *testing.T) {
+		reminders, err := GetDueContractAlerts(db, now)
+		assert.NoError(t, err)
+		assert.Len(t, reminders, 1)
+		assert.Equal(t, due.ID, reminders[0].ID)
+	})
+
+	t.Run("Alerts notify lawyer and client once", func(t *testing.T) {
+		assert.NoError(t, SendContractRenewalAlerts(db))
+
+		var notifications []models.Notification
+		db.Where("type = ?", models.NotificationTypeContractRenewal).Find(&notifications)
+		assert.Len(t, notifications, 2)
+
+		var reloaded models.ContractReminder
+		db.First(&reloaded, "id = ?", due.ID)
+		assert.NotNil(t, reloaded.AlertSentAt)
+
+		// The client's copy is logged once the async send finishes
+		assert.Eventually(t, func() bool {
+			var logs int64
+			db.Model(&models.EmailLog{}).Where("recipient_id = ? AND category = ?", client.ID, models.EmailCategoryContractRenewal).Count(&logs)
+			return logs == 1
+		}, 2*time.Second, 20*time.Millisecond)
+
+		assert.NoError(t, SendContractRenewalAlerts(db))
+		var count int64
+		db.Model(&models.Notification{}).Count(&count)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("Upcoming renewals are scoped by role", func(t *testing.T) {
+		renewals, err := GetUpcomingRenewals(db, firm.ID, lawyer, 5)
+		assert.NoError(t, err)
+		assert.Len(t, renewals, 2)
+		assert.Equal(t, due.ID, renewals[0].ID)
+
+		otherLawyer := &models.User{ID: "other", Role: "lawyer"}
+		renewals, err = GetUpcomingRenewals(db, firm.ID, otherLawyer, 5)
+		assert.NoError(t, err)
+		assert.Empty(t, renewals)
+	})
+
+	t.Run("Staff without contract access see no renewals", func(t *testing.T) {
+		staff := &models.User{ID: "staff", Role: "staff"}
+		renewals, err := GetUpcomingRenewals(db, firm.ID, staff, 5)
+		assert.NoError(t, err)
+		assert.Empty(t, renewals)
+
+		admin := &models.User{ID: "admin", Role: "admin"}
+		renewals, err = GetUpcomingRenewals(db, firm.ID, admin, 5)
+		assert.NoError(t, err)
+		assert.Len(t, renewals, 2)
+	})
+}
+
+func TestRollOverAutoRenewals(t *testing.T) {
+	db, firm, lawyer, client := setupContractReminderTestDB(t)
+	sent := time.Now().AddDate(0, -1, 0)
+
+	reminder := models.ContractReminder{
+		FirmID: firm.ID, ClientID: client.ID, ResponsibleLawyerID: lawyer.ID, Title: "Lease",
+		RenewalDate: day(2025, time.January, 15), NoticeDeadline: day(2025, time.January, 10),
+		NoticeBusinessDays: 5, AutoRenews: true, RenewalTermMonths: 12,
+		AlertSentAt: &sent, Status: models.ContractStatusActive,
+	}
+	db.Create(&reminder)
+
+	assert.NoError(t, RollOverAutoRenewals(db, day(2025, time.March, 1)))
+
+	var reloaded models.ContractReminder
+	db.First(&reloaded, "id = ?", reminder.ID)
+	assert.True(t, reloaded.RenewalDate.Equal(day(2026, time.January, 15)))
+	// 2026-01-12 is the Reyes Magos holiday (moved Monday)
+	assert.True(t, reloaded.NoticeDeadline.Equal(day(2026, time.January, 7)))
+	assert.Nil(t, reloaded.AlertSentAt)
+}
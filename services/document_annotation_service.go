@@ -0,0 +1,162 @@
+package services
+
+import (
+	"errors"
+	"law_flow_app_go/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const maxAnnotationRects = 200 // A highlight spanning many lines has one rect per line
+
+// Document annotation errors
+var (
+	ErrAnnotationNotFound        = errors.New("annotation not found")
+	ErrInvalidAnnotationType     = errors.New("invalid annotation type")
+	ErrInvalidAnnotationPage     = errors.New("page must be 1 or greater")
+	ErrInvalidAnnotationRects    = errors.New("annotation areas must lie within the page")
+	ErrAnnotationCommentRequired = errors.New("a comment is required")
+)
+
+// AnnotationInput holds the data needed to create an annotation
+type AnnotationInput struct {
+	Type         string
+	Page         int
+	Rects        models.AnnotationRects
+	Color        string
+	SelectedText string
+	Comment      string
+	IsShared     bool
+}
+
+// AnnotationUpdate holds the editable fields of an annotation; nil fields are left unchanged
+type AnnotationUpdate struct {
+	Comment  *string
+	Color    *string
+	IsShared *bool
+}
+
+// validateAnnotationRects checks that every area is page-relative
+func validateAnnotationRects(rects models.AnnotationRects) error {
+	if len(rects) > maxAnnotationRects {
+		return ErrInvalidAnnotationRects
+	}
+	for _, rect := range rects {
+		if rect.X < 0 || rect.Y < 0 || rect.Width < 0 || rect.Height < 0 ||
+			rect.X+rect.Width > 1 || rect.Y+rect.Height > 1 {
+			return ErrInvalidAnnotationRects
+		}
+	}
+	return nil
+}
+
+// CreateDocumentAnnotation adds an annotation by a user on a case document.
+// Highlights need at least one area, pins and comments a single point, and comments a text.
+func CreateDocumentAnnotation(db *gorm.DB, document *models.CaseDocument, userID string, input AnnotationInput) (*models.DocumentAnnotation, error) {
+	if !models.IsValidAnnotationType(input.Type) {
+		return nil, ErrInvalidAnnotationType
+	}
+	if input.Page < 1 {
+		return nil, ErrInvalidAnnotationPage
+	}
+	if err := validateAnnotationRects(input.Rects); err != nil {
+		return nil, err
+	}
+
+	comment := strings.TrimSpace(input.Comment)
+	switch input.Type {
+	case models.AnnotationTypeHighlight:
+		if len(input.Rects) == 0 {
+			return nil, ErrInvalidAnnotationRects
+		}
+	case models.AnnotationTypeComment, models.AnnotationTypePin:
+		if len(input.Rects) != 1 {
+			return nil, ErrInvalidAnnotationRects
+		}
+		if input.Type == models.AnnotationTypeComment && comment == "" {
+			return nil, ErrAnnotationCommentRequired
+		}
+	}
+
+	annotation := &models.DocumentAnnotation{
+		FirmID:       document.FirmID,
+		CaseID:       *document.CaseID,
+		DocumentID:   document.ID,
+		UserID:       userID,
+		Type:         input.Type,
+		Page:         input.Page,
+		Rects:        input.Rects,
+		Color:        strings.TrimSpace(input.Color),
+		SelectedText: strings.TrimSpace(input.SelectedText),
+		Comment:      comment,
+		IsShared:     input.IsShared,
+	}
+	if err := db.Create(annotation).Error; err != nil {
+		return nil, err
+	}
+	return annotation, nil
+}
+
+// GetDocumentAnnotations returns the annotations a user can see on a document: their own
+// and those shared with the case team, in page order
+func GetDocumentAnnotations(db *gorm.DB, documentID, userID string) ([]models.DocumentAnnotation, error) {
+	var annotations []models.DocumentAnnotation
+	err := db.Preload("User", func(tx *gorm.DB) *gorm.DB { return tx.Select("id", "name") }).
+		Where("document_id = ? AND (user_id = ? OR is_shared = ?)", documentID, userID, true).
+		Order("page ASC, created_at ASC").
+		Find(&annotations).Error
+	return annotations, err
+}
+
+// getOwnAnnotation fetches an annotation of a document written by the user. Shared
+// annotations can be read by the team but only changed by their author.
+func getOwnAnnotation(db *gorm.DB, documentID, annotationID, userID string) (*models.DocumentAnnotation, error) {
+	var annotation models.DocumentAnnotation
+	if err := db.Where("id = ? AND document_id = ? AND user_id = ?", annotationID, documentID, userID).
+		First(&annotation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAnnotationNotFound
+		}
+		return nil, err
+	}
+	return &annotation, nil
+}
+
+// UpdateDocumentAnnotation edits the comment, color or sharing of a user's own annotation
+func UpdateDocumentAnnotation(db *gorm.DB, documentID, annotationID, userID string, update AnnotationUpdate) (*models.DocumentAnnotation, error) {
+	annotation, err := getOwnAnnotation(db, documentID, annotationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if update.Comment != nil {
+		comment := strings.TrimSpace(*update.Comment)
+		if annotation.Type == models.AnnotationTypeComment && comment == "" {
+			return nil, ErrAnnotationCommentRequired
+		}
+		updates["comment"] = comment
+	}
+	if update.Color != nil {
+		updates["color"] = strings.TrimSpace(*update.Color)
+	}
+	if update.IsShared != nil {
+		updates["is_shared"] = *update.IsShared
+	}
+	if len(updates) > 0 {
+		if err := db.Model(annotation).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+	return annotation, nil
+}
+
+// DeleteDocumentAnnotation removes a user's own annotation
+func DeleteDocumentAnnotation(db *gorm.DB, documentID, annotationID, userID string) error {
+	annotation, err := getOwnAnnotation(db, documentID, annotationID, userID)
+	if err != nil {
+		return err
+	}
+	return db.Delete(annotation).Error
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAnnotationTestDB(t *testing.T) (*gorm.DB, *models.CaseDocument) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.CaseDocument{}, &models.DocumentAnnotation{}))
+
+	caseID := "case-ann"
+	doc := &models.CaseDocument{FirmID: "firm-ann", CaseID: &caseID, FileName: "brief.pdf", FileOriginalName: "brief.pdf"}
+	require.NoError(t, db.Create(doc).Error)
+	return db, doc
+}
+
+func TestCreateDocumentAnnotationValidation(t *testing.T) {
+	db, doc := setupAnnotationTestDB(t)
+	point := models.AnnotationRects{{X: 0.5, Y: 0.5}}
+
+	tests := []struct {
+		name  string
+		input AnnotationInput
+		err   error
+	}{
+		{"unknown type", AnnotationInput{Type: "stamp", Page: 1, Rects: point}, ErrInvalidAnnotationType},
+		{"page zero", AnnotationInput{Type: models.AnnotationTypePin, Page: 0, Rects: point}, ErrInvalidAnnotationPage},
+		{"highlight without areas", AnnotationInput{Type: models.AnnotationTypeHighlight, Page: 1}, ErrInvalidAnnotationRects},
+		{"area outside the page", AnnotationInput{Type: models.AnnotationTypeHighlight, Page: 1,
+			Rects: models.AnnotationRects{{X: 0.8, Y: 0.1, Width: 0.3, Height: 0.05}}}, ErrInvalidAnnotationRects},
+		{"pin with two points", AnnotationInput{Type: models.AnnotationTypePin, Page: 1,
+			Rects: models.AnnotationRects{{X: 0.1, Y: 0.1}, {X: 0.2, Y: 0.2}}}, ErrInvalidAnnotationRects},
+		{"empty comment", AnnotationInput{Type: models.AnnotationTypeComment, Page: 1, Rects: point, Comment: "  "}, ErrAnnotationCommentRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateDocumentAnnotation(db, doc, "user-1", tt.input)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	annotation, err := CreateDocumentAnnotation(db, doc, "user-1", AnnotationInput{
+		Type: models.AnnotationTypeHighlight, Page: 2, SelectedText: "force majeure",
+		Rects: models.AnnotationRects{{X: 0.1, Y: 0.2, Width: 0.5, Height: 0.02}, {X: 0.1, Y: 0.22, Width: 0.3, Height: 0.02}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "case-ann", annotation.CaseID)
+	assert.Equal(t, "firm-ann", annotation.FirmID)
+
+	var stored models.DocumentAnnotation
+	require.NoError(t, db.First(&stored, "id = ?", annotation.ID).Error)
+	assert.Len(t, stored.Rects, 2)
+	assert.Equal(t, 0.22, stored.Rects[1].Y)
+}
+
+func TestDocumentAnnotationVisibility(t *testing.T) {
+	db, doc := setupAnnotationTestDB(t)
+	point := models.AnnotationRects{{X: 0.5, Y: 0.5}}
+
+	private, err := CreateDocumentAnnotation(db, doc, "author", AnnotationInput{Type: models.AnnotationTypePin, Page: 3, Rects: point})
+	require.NoError(t, err)
+	shared, err := CreateDocumentAnnotation(db, doc, "author", AnnotationInput{Type: models.AnnotationTypeComment, Page: 1, Rects: point,
+		Comment: "Check against the signed version", IsShared: true})
+	require.NoError(t, err)
+
+	own, err := GetDocumentAnnotations(db, doc.ID, "author")
+	require.NoError(t, err)
+	require.Len(t, own, 2)
+	assert.Equal(t, shared.ID, own[0].ID, "annotations are ordered by page")
+
+	team, err := GetDocumentAnnotations(db, doc.ID, "teammate")
+	require.NoError(t, err)
+	require.Len(t, team, 1)
+	assert.Equal(t, shared.ID, team[0].ID)
+
+	// Teammates can read shared annotations but not change them
+	comment := "Rewritten"
+	_, err = UpdateDocumentAnnotation(db, doc.ID, shared.ID, "teammate", AnnotationUpdate{Comment: &comment})
+	assert.ErrorIs(t, err, ErrAnnotationNotFound)
+	assert.ErrorIs(t, DeleteDocumentAnnotation(db, doc.ID, shared.ID, "teammate"), ErrAnnotationNotFound)
+
+	// Sharing the private pin makes it visible to the team
+	isShared := true
+	updated, err := UpdateDocumentAnnotation(db, doc.ID, private.ID, "author", AnnotationUpdate{IsShared: &isShared})
+	require.NoError(t, err)
+	assert.True(t, updated.IsShared)
+	team, err = GetDocumentAnnotations(db, doc.ID, "teammate")
+	require.NoError(t, err)
+	assert.Len(t, team, 2)
+
+	require.NoError(t, DeleteDocumentAnnotation(db, doc.ID, private.ID, "author"))
+	own, err = GetDocumentAnnotations(db, doc.ID, "author")
+	require.NoError(t, err)
+	assert.Len(t, own, 1)
+}
@@ -59,6 +59,15 @@ func DeleteCaseDocument(db *gorm.DB, documentID string, userID string, firmID st
 		return fmt.Errorf("document not found: %w", err)
 	}
 
+	// Held documents cannot be deleted by anyone until the hold is released
+	held, err := IsDocumentUnderLegalHold(db, document.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check legal hold: %w", err)
+	}
+	if held {
+		return ErrDocumentUnderLegalHold
+	}
+
 	// Delete physical file from storage
 	if document.FilePath != "" {
 		// Use background context for deletion as this is a cleanup task
@@ -74,6 +83,9 @@ func DeleteCaseDocument(db *gorm.DB, documentID string, userID string, firmID st
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete document: %w", result.Error)
 	}
+	if err := db.Where("document_id = ?", document.ID).Delete(&models.DocumentAnnotation{}).Error; err != nil {
+		log.Printf("Warning: failed to delete annotations of document %s: %v", document.ID, err)
+	}
 
 	// Audit log handled by handler or caller
 	log.Printf("Document %s deleted by user %s", documentID, userID)
@@ -46,6 +46,19 @@ func (m *MockStorageProvider) Get(ctx context.Context, key string) (io.ReadClose
 	return args.Get(0).(io.ReadCloser), args.String(1), args.Error(2)
 }
 
+func (m *MockStorageProvider) Exists(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStorageProvider) GetRange(ctx context.Context, key string, byteRange string) (*StorageObject, error) {
+	args := m.Called(ctx, key, byteRange)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*StorageObject), args.Error(1)
+}
+
 func (m *MockStorageProvider) GetSignedURL(ctx context.Context, key string, expiration time.Duration) (string, error) {
 	args := m.Called(ctx, key, expiration)
 	return args.String(0), args.Error(1)
@@ -66,7 +79,7 @@ func setupDocumentTestDB() *gorm.DB {
 	if err != nil {
 		panic("failed to connect database")
 	}
-	db.AutoMigrate(&models.CaseDocument{}, &models.Case{}, &models.Firm{}, &models.User{})
+	db.AutoMigrate(&models.CaseDocument{}, &models.Case{}, &models.Firm{}, &models.User{}, &models.LegalHold{}, &models.DocumentAnnotation{})
 	return db
 }
 
@@ -130,3 +143,28 @@ func TestGetCaseDocuments(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, docs, 2)
 }
+
+func TestDeleteCaseDocumentUnderLegalHold(t *testing.T) {
+	db := setupDocumentTestDB()
+	firmID := "firm-hold"
+	caseID := "case-hold"
+
+	// No storage expectations: a held document must never reach storage deletion
+	mStorage := new(MockStorageProvider)
+	oldStorage := Storage
+	Storage = mStorage
+	defer func() { Storage = oldStorage }()
+
+	doc := models.CaseDocument{FirmID: firmID, CaseID: &caseID, FileName: "held.pdf", FilePath: "held.pdf"}
+	db.Create(&doc)
+	hold := models.LegalHold{FirmID: firmID, CaseID: caseID, Reason: "Litigation", CustodianID: "custodian", PlacedByID: "admin"}
+	db.Create(&hold)
+
+	err := DeleteCaseDocument(db, doc.ID, "admin", firmID)
+	assert.ErrorIs(t, err, ErrDocumentUnderLegalHold)
+
+	var count int64
+	db.Model(&models.CaseDocument{}).Where("id = ?", doc.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+	mStorage.AssertExpectations(t)
+}
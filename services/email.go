@@ -51,6 +51,7 @@ func buildEmailWithFallback(templateName string, lang string, tmplData interface
 
 // Email represents an email message
 type Email struct {
+	From        string // Sender address, cfg.EmailFrom when empty
 	To          []string
 	Subject     string
 	HTMLBody    string
@@ -111,23 +112,33 @@ func loadTemplate(templateName string, lang string, data interface{}) (html stri
 
 // SendEmail sends an email using Resend API
 func SendEmail(cfg *config.Config, email *Email) error {
+	_, err := deliverEmail(cfg, email)
+	return err
+}
+
+// deliverEmail sends an email and returns the provider message ID (empty in development mode)
+func deliverEmail(cfg *config.Config, email *Email) (string, error) {
 	// In development mode, log the email instead of sending
 	if cfg.EmailTestMode {
 		logEmailToConsole(email)
 		log.Printf("✅ Email logged successfully (development mode - not actually sent)")
-		return nil // Return early in development mode
+		return "", nil // Return early in development mode
 	}
 
 	// Validate configuration
 	if cfg.ResendAPIKey == "" {
-		return fmt.Errorf("RESEND_API_KEY not configured")
+		return "", fmt.Errorf("RESEND_API_KEY not configured")
 	}
 
 	// Create Resend client
 	client := resend.NewClient(cfg.ResendAPIKey)
 
 	// Build the from address
-	fromAddress := fmt.Sprintf("%s <%s>", cfg.EmailFromName, cfg.EmailFrom)
+	from := cfg.EmailFrom
+	if email.From != "" {
+		from = email.From
+	}
+	fromAddress := fmt.Sprintf("%s <%s>", cfg.EmailFromName, from)
 
 	// Create email params
 	params := &resend.SendEmailRequest{
@@ -154,17 +165,17 @@ func SendEmail(cfg *config.Config, email *Email) error {
 
 	// Validate we have at least one body
 	if params.Html == "" && params.Text == "" {
-		return fmt.Errorf("email must have either HTMLBody or TextBody")
+		return "", fmt.Errorf("email must have either HTMLBody or TextBody")
 	}
 
 	// Send email via Resend
 	sent, err := client.Emails.Send(params)
 	if err != nil {
-		return fmt.Errorf("failed to send email via Resend: %v", err)
+		return "", fmt.Errorf("failed to send email via Resend: %v", err)
 	}
 
 	log.Printf("Email sent successfully via Resend (ID: %s) to: %v", sent.Id, email.To)
-	return nil
+	return sent.Id, nil
 }
 
 // logEmailToConsole logs email details to console in development mode
@@ -369,6 +380,71 @@ func BuildLawyerAppointmentNotificationEmail(lawyerEmail string, data LawyerAppo
 	return email
 }
 
+// ContractRenewalReminderEmailData contains data for the contract renewal reminder email
+type ContractRenewalReminderEmailData struct {
+	RecipientName  string
+	FirmName       string
+	ClientName     string
+	ContractTitle  string
+	Counterparty   string
+	RenewalDate    string
+	NoticeDeadline string
+	NoticeDays     int
+	AutoRenews     bool
+	Link           string
+}
+
+// BuildContractRenewalReminderEmail creates a reminder email for an upcoming contract notice deadline
+func BuildContractRenewalReminderEmail(toEmail string, data ContractRenewalReminderEmailData, lang string) *Email {
+	email := buildEmailWithFallback("contract_renewal_reminder", lang, data, toEmail)
+	email.Subject = i18n.Translate(lang, "email.subject.contract_renewal_reminder", map[string]interface{}{
+		"title":    data.ContractTitle,
+		"deadline": data.NoticeDeadline,
+	})
+	return email
+}
+
+// AgendaDigestEmailData contains data for the morning agenda digest email
+type AgendaDigestEmailData struct {
+	RecipientName string
+	FirmName      string
+	Period        string // day or week
+	Date          string
+	ItemCount     int
+	Link          string
+}
+
+// BuildAgendaDigestEmail creates the morning agenda email; the caller attaches the agenda PDF
+func BuildAgendaDigestEmail(toEmail string, data AgendaDigestEmailData, lang string) *Email {
+	email := buildEmailWithFallback("agenda_digest", lang, data, toEmail)
+	email.Subject = i18n.Translate(lang, "email.subject.agenda_digest_"+data.Period, map[string]interface{}{
+		"date": data.Date,
+	})
+	return email
+}
+
+// ExternalCollaboratorInviteEmailData contains data for the external collaborator invitation email
+type ExternalCollaboratorInviteEmailData struct {
+	CollaboratorName string
+	InviterName      string
+	FirmName         string
+	CaseNumber       string
+	ClientName       string
+	ExpiresAt        string
+	NewAccount       bool
+	Link             string
+}
+
+// BuildExternalCollaboratorInviteEmail creates the invitation email for an external lawyer granted access to a case
+func BuildExternalCollaboratorInviteEmail(toEmail string, data ExternalCollaboratorInviteEmailData, lang string) *Email {
+	email := buildEmailWithFallback("external_collaborator_invite", lang, data, toEmail)
+	email.Subject = i18n.Translate(lang, "email.subject.external_collaborator_invite", map[string]interface{}{
+		"firmName":   data.FirmName,
+		"caseNumber": data.CaseNumber,
+	})
+	return email
+}
+
 // NewUserWelcomeEmailData contains data for the new user welcome email
 type NewUserWelcomeEmailData struct {
 	UserName  string
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"law_flow_app_go/config"
+	"law_flow_app_go/models"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Email webhook errors
+var (
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrWebhookTimestamp        = errors.New("webhook timestamp outside tolerance")
+)
+
+// webhookTolerance bounds the age of accepted webhook deliveries to prevent replays
+const webhookTolerance = 5 * time.Minute
+
+// Resend webhook event types handled by the email log
+const (
+	ResendEventDelivered = "email.delivered"
+	ResendEventBounced   = "email.bounced"
+	ResendEventOpened    = "email.opened"
+	ResendEventClicked   = "email.clicked"
+)
+
+// EmailLogEntry describes who a logged email belongs to
+type EmailLogEntry struct {
+	FirmID      string
+	RecipientID *string
+	CaseID      *string
+	Category    string
+}
+
+// ResendWebhookEvent is the payload Resend posts for email events
+type ResendWebhookEvent struct {
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      struct {
+		EmailID string `json:"email_id"`
+		Click   *struct {
+			Link string `json:"link"`
+		} `json:"click,omitempty"`
+	} `json:"data"`
+}
+
+// trackingSender returns the sender address for a firm's notification emails. Resend tracks
+// opens and clicks per sending domain, so only firms that opted in send from the tracked
+// domain; everyone else sends from the default one, which has tracking off
+func trackingSender(cfg *config.Config, trackingEnabled bool) (string, bool) {
+	if !trackingEnabled || cfg.EmailTrackingFrom == "" {
+		return "", false
+	}
+	return cfg.EmailTrackingFrom, true
+}
+
+// SendLoggedEmail sends a firm notification email and records it in the email log.
+// Open/click tracking applies only when the firm has it enabled and a tracked sender is set.
+func SendLoggedEmail(db *gorm.DB, cfg *config.Config, email *Email, entry EmailLogEntry) error {
+	var firm models.Firm
+	trackingRequested := false
+	if err := db.Select("id", "email_tracking_enabled").First(&firm, "id = ?", entry.FirmID).Error; err == nil {
+		trackingRequested = firm.EmailTrackingEnabled
+	}
+	from, trackingEnabled := trackingSender(cfg, trackingRequested)
+	outgoing := *email
+	outgoing.From = from
+
+	emailLog := &models.EmailLog{
+		FirmID:          entry.FirmID,
+		RecipientID:     entry.RecipientID,
+		CaseID:          entry.CaseID,
+		ToEmail:         strings.Join(email.To, ", "),
+		Subject:         email.Subject,
+		Category:        entry.Category,
+		Status:          models.EmailLogStatusSent,
+		SentAt:          time.Now(),
+		TrackingEnabled: trackingEnabled,
+	}
+
+	messageID, sendErr := deliverEmail(cfg, &outgoing)
+	if sendErr != nil {
+		errMsg := sendErr.Error()
+		emailLog.Status = models.EmailLogStatusFailed
+		emailLog.Error = &errMsg
+	}
+	if messageID != "" {
+		emailLog.ProviderMessageID = &messageID
+	}
+
+	if err := db.Create(emailLog).Error; err != nil {
+		log.Printf("Error recording email log for %v: %v", email.To, err)
+	}
+	return sendErr
+}
+
+// SendLoggedEmailAsync sends and logs an email in a goroutine
+func SendLoggedEmailAsync(db *gorm.DB, cfg *config.Config, email *Email, entry EmailLogEntry) {
+	emailCopy := &Email{
+		From:        email.From,
+		To:          append([]string{}, email.To...),
+		Subject:     email.Subject,
+		HTMLBody:    email.HTMLBody,
+		TextBody:    email.TextBody,
+		Attachments: append([]Attachment{}, email.Attachments...),
+	}
+
+	go func() {
+		if err := SendLoggedEmail(db, cfg, emailCopy, entry); err != nil {
+			log.Printf("Error sending logged email to %v: %v", emailCopy.To, err)
+		}
+	}()
+}
+
+// VerifyResendWebhook checks the Svix signature headers Resend attaches to webhook deliveries
+func VerifyResendWebhook(secret, msgID, timestamp, signatureHeader string, body []byte, now time.Time) error {
+	if secret == "" || msgID == "" || timestamp == "" || signatureHeader == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+	sentAt := time.Unix(ts, 0)
+	if now.Sub(sentAt) > webhookTolerance || sentAt.Sub(now) > webhookTolerance {
+		return ErrWebhookTimestamp
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msgID + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	// The header holds space-separated "v1,<base64>" signatures (several during secret rotation)
+	for _, candidate := range strings.Fields(signatureHeader) {
+		version, sig, found := strings.Cut(candidate, ",")
+		if !found || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(sig)
+		if err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidWebhookSignature
+}
+
+// ParseResendWebhookEvent decodes a webhook payload
+func ParseResendWebhookEvent(body []byte) (*ResendWebhookEvent, error) {
+	var event ResendWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// ApplyEmailEvent updates the matching email log with a provider event.
+// Events for unknown messages are ignored, and opens/clicks are dropped when tracking was off.
+func ApplyEmailEvent(db *gorm.DB, event *ResendWebhookEvent) error {
+	if event.Data.EmailID == "" {
+		return nil
+	}
+
+	var emailLog models.EmailLog
+	if err := db.First(&emailLog, "provider_message_id = ?", event.Data.EmailID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	at := event.CreatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	updates := map[string]interface{}{}
+	switch event.Type {
+	case ResendEventDelivered:
+		updates["status"] = models.EmailLogStatusDelivered
+		updates["delivered_at"] = at
+	case ResendEventBounced:
+		updates["status"] = models.EmailLogStatusBounced
+	case ResendEventOpened:
+		if !emailLog.TrackingEnabled {
+			return nil
+		}
+		updates["open_count"] = gorm.Expr("open_count + 1")
+		updates["last_opened_at"] = at
+		if emailLog.FirstOpenedAt == nil {
+			updates["first_opened_at"] = at
+		}
+	case ResendEventClicked:
+		if !emailLog.TrackingEnabled {
+			return nil
+		}
+		updates["click_count"] = gorm.Expr("click_count + 1")
+		updates["last_clicked_at"] = at
+		if event.Data.Click != nil && event.Data.Click.Link != "" {
+			updates["last_clicked_url"] = event.Data.Click.Link
+		}
+	default:
+		return nil
+	}
+
+	return db.Model(&emailLog).Updates(updates).Error
+}
+
+// GetClientEmailLogs returns the most recent notification emails sent to a client
+func GetClientEmailLogs(db *gorm.DB, firmID, clientID string, limit int) ([]models.EmailLog, error) {
+	var logs []models.EmailLog
+	err := db.Preload("Case").
+		Where("firm_id = ? AND recipient_id = ?", firmID, clientID).
+		Order("sent_at DESC").
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
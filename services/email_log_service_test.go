@@ -0,0 +1,135 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"law_flow_app_go/config"
+	"law_flow_app_go/models"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupEmailLogTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Firm{}, &models.User{}, &models.Case{}, &models.EmailLog{}))
+	return db
+}
+
+func signWebhook(secret, msgID, timestamp string, body []byte) string {
+	key, _ := base64.StdEncoding.DecodeString(secret[len("whsec_"):])
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msgID + "." + timestamp + "."))
+	mac.Write(body)
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyResendWebhook(t *testing.T) {
+	secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("super-secret-signing-key"))
+	body := []byte(`{"type":"email.opened","data":{"email_id":"msg_1"}}`)
+	now := time.Unix(1760000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := signWebhook(secret, "evt_1", timestamp, body)
+
+	assert.NoError(t, VerifyResendWebhook(secret, "evt_1", timestamp, signature, body, now))
+	// Rotated secrets send several signatures
+	assert.NoError(t, VerifyResendWebhook(secret, "evt_1", timestamp, "v1,bm9wZQ== "+signature, body, now))
+
+	assert.ErrorIs(t, VerifyResendWebhook(secret, "evt_1", timestamp, signature, []byte(`{"tampered":true}`), now), ErrInvalidWebhookSignature)
+	assert.ErrorIs(t, VerifyResendWebhook(secret, "evt_2", timestamp, signature, body, now), ErrInvalidWebhookSignature)
+	assert.ErrorIs(t, VerifyResendWebhook("", "evt_1", timestamp, signature, body, now), ErrInvalidWebhookSignature)
+	assert.ErrorIs(t, VerifyResendWebhook(secret, "evt_1", timestamp, signature, body, now.Add(10*time.Minute)), ErrWebhookTimestamp)
+}
+
+func TestSendLoggedEmail(t *testing.T) {
+	db := setupEmailLogTestDB(t)
+	cfg := &config.Config{EmailTestMode: true, EmailTrackingFrom: "noreply@tracked.test"}
+
+	firm := &models.Firm{Name: "Tracked Firm", BillingEmail: "billing@test.com", EmailTrackingEnabled: true}
+	db.Create(firm)
+	client := &models.User{FirmID: &firm.ID, Name: "Client", Email: "client@test.com", Role: "client", IsActive: true}
+	db.Create(client)
+
+	email := &Email{To: []string{client.Email}, Subject: "Appointment confirmed", TextBody: "See you soon"}
+	require.NoError(t, SendLoggedEmail(db, cfg, email, EmailLogEntry{
+		FirmID: firm.ID, RecipientID: &client.ID, Category: models.EmailCategoryAppointmentConfirmation,
+	}))
+
+	logs, err := GetClientEmailLogs(db, firm.ID, client.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "Appointment confirmed", logs[0].Subject)
+	assert.Equal(t, models.EmailLogStatusSent, logs[0].Status)
+	assert.True(t, logs[0].TrackingEnabled)
+}
+
+func TestTrackingSender(t *testing.T) {
+	cfg := &config.Config{EmailFrom: "noreply@untracked.test", EmailTrackingFrom: "noreply@tracked.test"}
+
+	from, tracked := trackingSender(cfg, true)
+	assert.Equal(t, "noreply@tracked.test", from)
+	assert.True(t, tracked)
+
+	// Firms that did not opt in send from the default domain, which has tracking off
+	from, tracked = trackingSender(cfg, false)
+	assert.Empty(t, from)
+	assert.False(t, tracked)
+
+	// Without a tracked domain nobody is tracked, whatever the firm setting
+	from, tracked = trackingSender(&config.Config{EmailFrom: "noreply@untracked.test"}, true)
+	assert.Empty(t, from)
+	assert.False(t, tracked)
+}
+
+func TestApplyEmailEvent(t *testing.T) {
+	db := setupEmailLogTestDB(t)
+
+	trackedID := "msg_tracked"
+	untrackedID := "msg_untracked"
+	tracked := &models.EmailLog{FirmID: "firm-1", ToEmail: "a@test.com", Subject: "A", Category: models.EmailCategoryContractRenewal, ProviderMessageID: &trackedID, SentAt: time.Now(), TrackingEnabled: true}
+	untracked := &models.EmailLog{FirmID: "firm-2", ToEmail: "b@test.com", Subject: "B", Category: models.EmailCategoryContractRenewal, ProviderMessageID: &untrackedID, SentAt: time.Now()}
+	db.Create(tracked)
+	db.Create(untracked)
+
+	event := func(eventType, emailID, link string) *ResendWebhookEvent {
+		body := `{"type":"` + eventType + `","created_at":"2026-03-10T12:00:00Z","data":{"email_id":"` + emailID + `"`
+		if link != "" {
+			body += `,"click":{"link":"` + link + `"}`
+		}
+		parsed, err := ParseResendWebhookEvent([]byte(body + "}}"))
+		require.NoError(t, err)
+		return parsed
+	}
+
+	for _, id := range []string{trackedID, untrackedID} {
+		require.NoError(t, ApplyEmailEvent(db, event(ResendEventDelivered, id, "")))
+		require.NoError(t, ApplyEmailEvent(db, event(ResendEventOpened, id, "")))
+		require.NoError(t, ApplyEmailEvent(db, event(ResendEventOpened, id, "")))
+		require.NoError(t, ApplyEmailEvent(db, event(ResendEventClicked, id, "https://app.test/contracts")))
+	}
+	// Unknown messages are ignored
+	require.NoError(t, ApplyEmailEvent(db, event(ResendEventOpened, "msg_unknown", "")))
+
+	var reloaded models.EmailLog
+	db.First(&reloaded, "id = ?", tracked.ID)
+	assert.Equal(t, models.EmailLogStatusDelivered, reloaded.Status)
+	assert.Equal(t, 2, reloaded.OpenCount)
+	assert.NotNil(t, reloaded.FirstOpenedAt)
+	assert.Equal(t, 1, reloaded.ClickCount)
+	require.NotNil(t, reloaded.LastClickedURL)
+	assert.Equal(t, "https://app.test/contracts", *reloaded.LastClickedURL)
+
+	reloaded = models.EmailLog{}
+	db.First(&reloaded, "id = ?", untracked.ID)
+	assert.Equal(t, models.EmailLogStatusDelivered, reloaded.Status)
+	assert.Equal(t, 0, reloaded.OpenCount)
+	assert.Equal(t, 0, reloaded.ClickCount)
+	assert.Nil(t, reloaded.FirstOpenedAt)
+}
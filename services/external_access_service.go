@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"law_flow_app_go/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// External collaborator errors
+var (
+	ErrExternalAccessNotFound = errors.New("external access not found")
+	ErrExternalAccessRevoked  = errors.New("external access has already been revoked")
+	ErrExternalAccessExpiry   = errors.New("external access expiry must be in the future and within the allowed window")
+	ErrExternalEmailInUse     = errors.New("email belongs to an account that cannot be invited as an external collaborator")
+)
+
+// MaxExternalAccessDuration caps how far in the future an external grant can expire
+const MaxExternalAccessDuration = 365 * 24 * time.Hour
+
+// ExternalInvite holds the data needed to invite an external lawyer to a case
+type ExternalInvite struct {
+	FirmID      string
+	CaseID      string
+	InvitedByID string
+	Name        string
+	Email       string
+	ExpiresAt   time.Time
+}
+
+// InviteExternalCollaborator grants an external lawyer access to a case, creating their
+// constrained account on first invite. Re-inviting someone whose grant expired or was
+// revoked reactivates the same grant with the new expiry.
+// Returns the grant and whether a new account was created (so the caller can send a set-password link).
+func InviteExternalCollaborator(db *gorm.DB, invite ExternalInvite, now time.Time) (*models.CaseExternalAccess, bool, error) {
+	if !invite.ExpiresAt.After(now) || invite.ExpiresAt.Sub(now) > MaxExternalAccessDuration {
+		return nil, false, ErrExternalAccessExpiry
+	}
+
+	email := strings.ToLower(strings.TrimSpace(invite.Email))
+	var access models.CaseExternalAccess
+	created := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		err := tx.Where("email = ?", email).First(&user).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			// The account gets an unusable random password; the invitee sets their own via the reset link
+			token, err := GenerateSessionToken()
+			if err != nil {
+				return err
+			}
+			hash, err := HashPassword(token)
+			if err != nil {
+				return err
+			}
+			user = models.User{
+				Name:     strings.TrimSpace(invite.Name),
+				Email:    email,
+				Password: hash,
+				FirmID:   &invite.FirmID,
+				Role:     "external",
+				IsActive: true,
+			}
+			if err := tx.Create(&user).Error; err != nil {
+				return err
+			}
+			created = true
+		case err != nil:
+			return err
+		default:
+			if !user.IsExternal() || user.FirmID == nil || *user.FirmID != invite.FirmID {
+				return ErrExternalEmailInUse
+			}
+			if !user.IsActive {
+				if err := tx.Model(&user).Update("is_active", true).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		err = tx.Where("case_id = ? AND user_id = ?", invite.CaseID, user.ID).First(&access).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			access = models.CaseExternalAccess{
+				FirmID:      invite.FirmID,
+				CaseID:      invite.CaseID,
+				UserID:      user.ID,
+				InvitedByID: invite.InvitedByID,
+				ExpiresAt:   invite.ExpiresAt,
+			}
+			return tx.Create(&access).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&access).Updates(map[string]interface{}{
+			"invited_by_id": invite.InvitedByID,
+			"expires_at":    invite.ExpiresAt,
+			"revoked_at":    nil,
+			"revoked_by_id": nil,
+		}).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	db.Preload("User").First(&access, "id = ?", access.ID)
+	return &access, created, nil
+}
+
+// RevokeExternalAccess ends a grant immediately. When the collaborator has no other active
+// grants their sessions are dropped so they are signed out right away.
+func RevokeExternalAccess(db *gorm.DB, firmID, caseID, accessID, revokedByID string, now time.Time) (*models.CaseExternalAccess, error) {
+	var access models.CaseExternalAccess
+	if err := db.Preload("User").First(&access, "id = ? AND firm_id = ? AND case_id = ?", accessID, firmID, caseID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExternalAccessNotFound
+		}
+		return nil, err
+	}
+	if access.RevokedAt != nil {
+		return nil, ErrExternalAccessRevoked
+	}
+
+	if err := db.Model(&access).Updates(map[string]interface{}{
+		"revoked_at":    now,
+		"revoked_by_id": revokedByID,
+	}).Error; err != nil {
+		return nil, err
+	}
+	access.RevokedAt = &now
+	access.RevokedByID = &revokedByID
+
+	var remaining int64
+	db.Model(&models.CaseExternalAccess{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", access.UserID, now).
+		Count(&remaining)
+	if remaining == 0 {
+		if err := DeleteAllUserSessions(db, access.UserID); err != nil {
+			return &access, err
+		}
+	}
+
+	return &access, nil
+}
+
+// GetCaseExternalAccesses returns every grant on a case, newest first
+func GetCaseExternalAccesses(db *gorm.DB, firmID, caseID string) ([]models.CaseExternalAccess, error) {
+	var accesses []models.CaseExternalAccess
+	err := db.Preload("User").Preload("InvitedBy").Preload("RevokedBy").
+		Where("firm_id = ? AND case_id = ?", firmID, caseID).
+		Order("created_at DESC").
+		Find(&accesses).Error
+	return accesses, err
+}
+
+// GetActiveExternalAccesses returns the cases an external user can currently open
+func GetActiveExternalAccesses(db *gorm.DB, userID string, now time.Time) ([]models.CaseExternalAccess, error) {
+	var accesses []models.CaseExternalAccess
+	err := db.Preload("Case").Preload("Case.Client").
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("expires_at ASC").
+		Find(&accesses).Error
+	return accesses, err
+}
+
+// ResolveExternalAccess returns the user's active grant on a case and records the access time
+func ResolveExternalAccess(db *gorm.DB, userID, caseID string, now time.Time) (*models.CaseExternalAccess, error) {
+	var access models.CaseExternalAccess
+	err := db.Preload("Case").Preload("Case.Client").
+		Where("user_id = ? AND case_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, caseID, now).
+		First(&access).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExternalAccessNotFound
+		}
+		return nil, err
+	}
+
+	db.Model(&access).UpdateColumn("last_accessed_at", now)
+	access.LastAccessedAt = &now
+	return &access, nil
+}
+
+// GetExternalSharedDocuments returns the case documents shared with external collaborators
+func GetExternalSharedDocuments(db *gorm.DB, firmID, caseID string) ([]models.CaseDocument, error) {
+	var documents []models.CaseDocument
+	err := db.Where("firm_id = ? AND case_id = ? AND shared_with_external = ?", firmID, caseID, true).
+		Order("created_at DESC").
+		Find(&documents).Error
+	return documents, err
+}
+
+// GetExternalSharedLogs returns the case log entries shared with external collaborators
+func GetExternalSharedLogs(db *gorm.DB, firmID, caseID string) ([]models.CaseLog, error) {
+	var logs []models.CaseLog
+	err := db.Where("firm_id = ? AND case_id = ? AND shared_with_external = ?", firmID, caseID, true).
+		Order("occurred_at DESC, created_at DESC").
+		Find(&logs).Error
+	return logs, err
+}
+
+// GetExternalCollaboratorActivity returns the audit trail left by external collaborators
+// on a case: opening it and viewing or downloading its shared documents and log entries
+func GetExternalCollaboratorActivity(db *gorm.DB, firmID, caseID string, limit int) ([]models.AuditLog, error) {
+	var logs []models.AuditLog
+	err := db.Where("firm_id = ? AND user_role = ?", firmID, "external").
+		Where(
+			db.Where("resource_id = ?", caseID).
+				Or("resource_id IN (?)", db.Unscoped().Model(&models.CaseDocument{}).Select("id").Where("case_id = ?", caseID)).
+				Or("resource_id IN (?)", db.Unscoped().Model(&models.CaseLog{}).Select("id").Where("case_id = ?", caseID)),
+		).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&logs).Error
+	return logs, err
+}
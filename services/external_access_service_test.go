@@ -0,0 +1,169 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupExternalAccessTestDB(t *testing.T) (*gorm.DB, *models.Firm, *models.User, *models.Case) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Firm{}, &models.User{}, &models.Session{}, &models.Case{},
+		&models.CaseDocument{}, &models.CaseLog{}, &models.AuditLog{}, &models.CaseExternalAccess{}))
+
+	firm := &models.Firm{Name: "Test Firm", BillingEmail: "billing@test.com"}
+	db.Create(firm)
+	lawyer := &models.User{FirmID: &firm.ID, Name: "Lead Lawyer", Email: "lead@test.com", Role: "lawyer", IsActive: true}
+	db.Create(lawyer)
+	client := &models.User{FirmID: &firm.ID, Name: "Client", Email: "client@test.com", Role: "client", IsActive: true}
+	db.Create(client)
+	caseRecord := &models.Case{
+		FirmID: firm.ID, ClientID: client.ID, CaseNumber: "CASE-EXT-1", CaseType: "Civil",
+		Description: "Test", Status: models.CaseStatusOpen, OpenedAt: time.Now(),
+	}
+	require.NoError(t, db.Create(caseRecord).Error)
+
+	return db, firm, lawyer, caseRecord
+}
+
+func TestInviteExternalCollaborator(t *testing.T) {
+	db, firm, lawyer, caseRecord := setupExternalAccessTestDB(t)
+	now := time.Now()
+
+	invite := ExternalInvite{
+		FirmID: firm.ID, CaseID: caseRecord.ID, InvitedByID: lawyer.ID,
+		Name: "Co Counsel", Email: " CoCounsel@Other.com ", ExpiresAt: now.AddDate(0, 1, 0),
+	}
+
+	access, created, err := InviteExternalCollaborator(db, invite, now)
+	require.NoError(t, err)
+	assert.True(t, created)
+	require.NotNil(t, access.User)
+	assert.Equal(t, "cocounsel@other.com", access.User.Email)
+	assert.True(t, access.User.IsExternal())
+	assert.False(t, access.User.IsBillable())
+	assert.True(t, access.IsActive(now))
+
+	// Re-inviting after revocation reactivates the same grant without a new account
+	_, err = RevokeExternalAccess(db, firm.ID, caseRecord.ID, access.ID, lawyer.ID, now)
+	require.NoError(t, err)
+	invite.ExpiresAt = now.AddDate(0, 2, 0)
+	again, created, err := InviteExternalCollaborator(db, invite, now)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, access.ID, again.ID)
+	var reloaded models.CaseExternalAccess
+	db.First(&reloaded, "id = ?", access.ID)
+	assert.Nil(t, reloaded.RevokedAt)
+	assert.True(t, reloaded.IsActive(now))
+
+	// Firm accounts and other firms' external accounts cannot be invited
+	invite.Email = lawyer.Email
+	_, _, err = InviteExternalCollaborator(db, invite, now)
+	assert.ErrorIs(t, err, ErrExternalEmailInUse)
+
+	otherFirm := &models.Firm{Name: "Other Firm", BillingEmail: "other@test.com"}
+	db.Create(otherFirm)
+	invite.FirmID = otherFirm.ID
+	invite.Email = "cocounsel@other.com"
+	_, _, err = InviteExternalCollaborator(db, invite, now)
+	assert.ErrorIs(t, err, ErrExternalEmailInUse)
+
+	// Expiry must be in the future and within the allowed window
+	invite.FirmID = firm.ID
+	invite.ExpiresAt = now.Add(-time.Hour)
+	_, _, err = InviteExternalCollaborator(db, invite, now)
+	assert.ErrorIs(t, err, ErrExternalAccessExpiry)
+	invite.ExpiresAt = now.Add(MaxExternalAccessDuration + time.Hour)
+	_, _, err = InviteExternalCollaborator(db, invite, now)
+	assert.ErrorIs(t, err, ErrExternalAccessExpiry)
+}
+
+func TestResolveExternalAccess(t *testing.T) {
+	db, firm, lawyer, caseRecord := setupExternalAccessTestDB(t)
+	now := time.Now()
+
+	access, _, err := InviteExternalCollaborator(db, ExternalInvite{
+		FirmID: firm.ID, CaseID: caseRecord.ID, InvitedByID: lawyer.ID,
+		Name: "Co Counsel", Email: "cocounsel@other.com", ExpiresAt: now.AddDate(0, 0, 7),
+	}, now)
+	require.NoError(t, err)
+
+	resolved, err := ResolveExternalAccess(db, access.UserID, caseRecord.ID, now)
+	require.NoError(t, err)
+	assert.Equal(t, caseRecord.CaseNumber, resolved.Case.CaseNumber)
+	var touched models.CaseExternalAccess
+	db.First(&touched, "id = ?", access.ID)
+	assert.NotNil(t, touched.LastAccessedAt)
+
+	// Other cases of the firm stay out of reach
+	otherCase := &models.Case{FirmID: firm.ID, ClientID: caseRecord.ClientID, CaseNumber: "CASE-EXT-2", CaseType: "Civil", Description: "Other", Status: models.CaseStatusOpen, OpenedAt: now}
+	db.Create(otherCase)
+	_, err = ResolveExternalAccess(db, access.UserID, otherCase.ID, now)
+	assert.ErrorIs(t, err, ErrExternalAccessNotFound)
+
+	// Expired grants no longer resolve
+	_, err = ResolveExternalAccess(db, access.UserID, caseRecord.ID, now.AddDate(0, 0, 8))
+	assert.ErrorIs(t, err, ErrExternalAccessNotFound)
+
+	// Revocation takes effect immediately and signs the collaborator out
+	session, err := CreateSession(db, access.UserID, firm.ID, "127.0.0.1", "test-agent")
+	require.NoError(t, err)
+	_, err = RevokeExternalAccess(db, firm.ID, caseRecord.ID, access.ID, lawyer.ID, now)
+	require.NoError(t, err)
+	_, err = ResolveExternalAccess(db, access.UserID, caseRecord.ID, now)
+	assert.ErrorIs(t, err, ErrExternalAccessNotFound)
+	_, err = ValidateSession(db, session.Token)
+	assert.Error(t, err)
+
+	_, err = RevokeExternalAccess(db, firm.ID, caseRecord.ID, access.ID, lawyer.ID, now)
+	assert.ErrorIs(t, err, ErrExternalAccessRevoked)
+}
+
+func TestExternalSharedContentAndActivity(t *testing.T) {
+	db, firm, lawyer, caseRecord := setupExternalAccessTestDB(t)
+	now := time.Now()
+
+	access, _, err := InviteExternalCollaborator(db, ExternalInvite{
+		FirmID: firm.ID, CaseID: caseRecord.ID, InvitedByID: lawyer.ID,
+		Name: "Co Counsel", Email: "cocounsel@other.com", ExpiresAt: now.AddDate(0, 1, 0),
+	}, now)
+	require.NoError(t, err)
+
+	shared := &models.CaseDocument{FirmID: firm.ID, CaseID: &caseRecord.ID, FileName: "a.pdf", FileOriginalName: "brief.pdf", FilePath: "a.pdf", FileSize: 1, SharedWithExternal: true}
+	private := &models.CaseDocument{FirmID: firm.ID, CaseID: &caseRecord.ID, FileName: "b.pdf", FileOriginalName: "strategy.pdf", FilePath: "b.pdf", FileSize: 1, IsPublic: true}
+	db.Create(shared)
+	db.Create(private)
+	db.Create(&models.CaseLog{FirmID: firm.ID, CaseID: caseRecord.ID, Title: "Hearing notes", SharedWithExternal: true, OccurredAt: &now})
+	db.Create(&models.CaseLog{FirmID: firm.ID, CaseID: caseRecord.ID, Title: "Internal memo", OccurredAt: &now})
+
+	documents, err := GetExternalSharedDocuments(db, firm.ID, caseRecord.ID)
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+	assert.Equal(t, "brief.pdf", documents[0].FileOriginalName)
+
+	logs, err := GetExternalSharedLogs(db, firm.ID, caseRecord.ID)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "Hearing notes", logs[0].Title)
+
+	// Activity is limited to external users and to this case's resources
+	externalID := access.UserID
+	db.Create(&models.AuditLog{UserID: &externalID, UserName: "Co Counsel", UserRole: "external", FirmID: &firm.ID, ResourceType: "Case", ResourceID: caseRecord.ID, ResourceName: caseRecord.CaseNumber, Action: models.AuditActionView})
+	db.Create(&models.AuditLog{UserID: &externalID, UserName: "Co Counsel", UserRole: "external", FirmID: &firm.ID, ResourceType: "CaseDocument", ResourceID: shared.ID, ResourceName: shared.FileOriginalName, Action: models.AuditActionDownload})
+	db.Create(&models.AuditLog{UserID: &externalID, UserName: "Co Counsel", UserRole: "external", FirmID: &firm.ID, ResourceType: "Case", ResourceID: "other-case", ResourceName: "OTHER", Action: models.AuditActionView})
+	db.Create(&models.AuditLog{UserID: &lawyer.ID, UserName: lawyer.Name, UserRole: "lawyer", FirmID: &firm.ID, ResourceType: "Case", ResourceID: caseRecord.ID, ResourceName: caseRecord.CaseNumber, Action: models.AuditActionView})
+
+	activity, err := GetExternalCollaboratorActivity(db, firm.ID, caseRecord.ID, 50)
+	require.NoError(t, err)
+	assert.Len(t, activity, 2)
+	for _, entry := range activity {
+		assert.Equal(t, "external", entry.UserRole)
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"law_flow_app_go/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// customRolePrefix marks a custom role in the user form role select, e.g. "custom:<id>"
+const customRolePrefix = "custom:"
+
+// Firm role errors
+var (
+	ErrFirmRoleNotFound          = errors.New("role not found")
+	ErrFirmRoleNameRequired      = errors.New("a role name is required")
+	ErrFirmRoleNameReserved      = errors.New("role name is reserved for a built-in role")
+	ErrFirmRoleDuplicate         = errors.New("a role with this name already exists")
+	ErrFirmRoleInvalidBase       = errors.New("base role must be lawyer or staff")
+	ErrFirmRoleInvalidCapability = errors.New("invalid capability")
+	ErrFirmRoleNoCapabilities    = errors.New("select at least one capability")
+	ErrFirmRoleInUse             = errors.New("role is assigned to users")
+)
+
+// FirmRoleInput holds the data needed to create or update a custom role
+type FirmRoleInput struct {
+	Name         string
+	Description  string
+	BaseRole     string
+	Capabilities []string
+}
+
+// normalizeCapabilities validates capabilities, adds the ones they imply and returns them
+// in matrix order
+func normalizeCapabilities(capabilities []string) (string, error) {
+	selected := make(map[string]bool, len(capabilities))
+	for _, capability := range capabilities {
+		if !models.IsValidCapability(capability) {
+			return "", ErrFirmRoleInvalidCapability
+		}
+		selected[capability] = true
+		if implied, ok := models.ImpliedCapability(capability); ok {
+			selected[implied] = true
+		}
+	}
+	if len(selected) == 0 {
+		return "", ErrFirmRoleNoCapabilities
+	}
+
+	ordered := make([]string, 0, len(selected))
+	for _, capability := range models.Capabilities {
+		if selected[capability] {
+			ordered = append(ordered, capability)
+		}
+	}
+	return strings.Join(ordered, ","), nil
+}
+
+// validateFirmRoleInput checks a role definition and returns its normalized capabilities
+func validateFirmRoleInput(db *gorm.DB, firmID, roleID string, input *FirmRoleInput) (string, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	if input.Name == "" {
+		return "", ErrFirmRoleNameRequired
+	}
+	if _, builtIn := models.RoleCapabilities[strings.ToLower(input.Name)]; builtIn {
+		return "", ErrFirmRoleNameReserved
+	}
+	if !models.IsValidCustomRoleBase(input.BaseRole) {
+		return "", ErrFirmRoleInvalidBase
+	}
+
+	var count int64
+	if err := db.Model(&models.FirmRole{}).
+		Where("firm_id = ? AND LOWER(name) = ? AND id <> ?", firmID, strings.ToLower(input.Name), roleID).
+		Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count > 0 {
+		return "", ErrFirmRoleDuplicate
+	}
+
+	return normalizeCapabilities(input.Capabilities)
+}
+
+// GetFirmRoles returns the custom roles of a firm by name
+func GetFirmRoles(db *gorm.DB, firmID string) ([]models.FirmRole, error) {
+	var roles []models.FirmRole
+	err := db.Where("firm_id = ?", firmID).Order("name ASC").Find(&roles).Error
+	return roles, err
+}
+
+// GetFirmRole fetches a custom role of a firm
+func GetFirmRole(db *gorm.DB, firmID, roleID string) (*models.FirmRole, error) {
+	var role models.FirmRole
+	if err := db.Where("id = ? AND firm_id = ?", roleID, firmID).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFirmRoleNotFound
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// CreateFirmRole defines a new custom role for a firm
+func CreateFirmRole(db *gorm.DB, firmID string, input FirmRoleInput) (*models.FirmRole, error) {
+	capabilities, err := validateFirmRoleInput(db, firmID, "", &input)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.FirmRole{
+		FirmID:       firmID,
+		Name:         input.Name,
+		Description:  input.Description,
+		BaseRole:     input.BaseRole,
+		Capabilities: capabilities,
+	}
+	if err := db.Create(role).Error; err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// UpdateFirmRole redefines a custom role. A new base role is applied to the users holding it.
+func UpdateFirmRole(db *gorm.DB, firmID, roleID string, input FirmRoleInput) (*models.FirmRole, error) {
+	role, err := GetFirmRole(db, firmID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	capabilities, err := validateFirmRoleInput(db, firmID, role.ID, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(role).Updates(map[string]interface{}{
+			"name":         input.Name,
+			"description":  input.Description,
+			"base_role":    input.BaseRole,
+			"capabilities": capabilities,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).
+			Where("firm_id = ? AND custom_role_id = ?", firmID, role.ID).
+			Update("role", input.BaseRole).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// DeleteFirmRole removes a custom role that no user holds anymore
+func DeleteFirmRole(db *gorm.DB, firmID, roleID string) (*models.FirmRole, error) {
+	role, err := GetFirmRole(db, firmID, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := db.Model(&models.User{}).Where("custom_role_id = ?", role.ID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrFirmRoleInUse
+	}
+	if err := db.Delete(role).Error; err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// CountFirmRoleUsers returns how many users hold each custom role of a firm
+func CountFirmRoleUsers(db *gorm.DB, firmID string) (map[string]int, error) {
+	var rows []struct {
+		CustomRoleID string
+		Count        int
+	}
+	if err := db.Model(&models.User{}).
+		Select("custom_role_id, COUNT(*) AS count").
+		Where("firm_id = ? AND custom_role_id IS NOT NULL", firmID).
+		Group("custom_role_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.CustomRoleID] = row.Count
+	}
+	return counts, nil
+}
+
+// CustomRoleOption returns the user form role select value of a custom role
+func CustomRoleOption(roleID string) string {
+	return customRolePrefix + roleID
+}
+
+// ResolveRoleSelection turns a user form role select value into the role to store and the
+// custom role to assign: a built-in role as is, or "custom:<id>" as its base role
+func ResolveRoleSelection(db *gorm.DB, firmID, value string) (string, *string, error) {
+	roleID, isCustom := strings.CutPrefix(value, customRolePrefix)
+	if !isCustom {
+		return value, nil, nil
+	}
+
+	role, err := GetFirmRole(db, firmID, roleID)
+	if err != nil {
+		return "", nil, err
+	}
+	return role.BaseRole, &role.ID, nil
+}
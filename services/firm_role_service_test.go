@@ -0,0 +1,112 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupFirmRoleTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Firm{}, &models.User{}, &models.FirmRole{}))
+	return db
+}
+
+func TestCreateFirmRole(t *testing.T) {
+	db := setupFirmRoleTestDB(t)
+
+	role, err := CreateFirmRole(db, "firm-1", FirmRoleInput{
+		Name:         " Paralegal ",
+		BaseRole:     "staff",
+		Capabilities: []string{models.CapabilitySearch, models.CapabilityCasesManage},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Paralegal", role.Name)
+	// Managing cases implies seeing them; capabilities are kept in matrix order
+	assert.Equal(t, []string{models.CapabilityCasesView, models.CapabilityCasesManage, models.CapabilitySearch}, role.CapabilityList())
+
+	tests := []struct {
+		name  string
+		input FirmRoleInput
+		err   error
+	}{
+		{"empty name", FirmRoleInput{Name: " ", BaseRole: "staff", Capabilities: []string{models.CapabilitySearch}}, ErrFirmRoleNameRequired},
+		{"built-in name", FirmRoleInput{Name: "Admin", BaseRole: "staff", Capabilities: []string{models.CapabilitySearch}}, ErrFirmRoleNameReserved},
+		{"duplicate name", FirmRoleInput{Name: "paralegal", BaseRole: "staff", Capabilities: []string{models.CapabilitySearch}}, ErrFirmRoleDuplicate},
+		{"admin base", FirmRoleInput{Name: "Partner", BaseRole: "admin", Capabilities: []string{models.CapabilitySearch}}, ErrFirmRoleInvalidBase},
+		{"unknown capability", FirmRoleInput{Name: "Partner", BaseRole: "lawyer", Capabilities: []string{"firm.settings"}}, ErrFirmRoleInvalidCapability},
+		{"no capabilities", FirmRoleInput{Name: "Partner", BaseRole: "lawyer"}, ErrFirmRoleNoCapabilities},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateFirmRole(db, "firm-1", tt.input)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	// Names are unique per firm only
+	_, err = CreateFirmRole(db, "firm-2", FirmRoleInput{Name: "Paralegal", BaseRole: "lawyer", Capabilities: []string{models.CapabilitySearch}})
+	assert.NoError(t, err)
+}
+
+func TestFirmRoleAssignment(t *testing.T) {
+	db := setupFirmRoleTestDB(t)
+	firmID := "firm-1"
+
+	role, err := CreateFirmRole(db, firmID, FirmRoleInput{Name: "Accountant", BaseRole: "staff",
+		Capabilities: []string{models.CapabilityServicesView, models.CapabilityReportsExport}})
+	require.NoError(t, err)
+
+	baseRole, customRoleID, err := ResolveRoleSelection(db, firmID, CustomRoleOption(role.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "staff", baseRole)
+	require.NotNil(t, customRoleID)
+	assert.Equal(t, role.ID, *customRoleID)
+
+	baseRole, customRoleID, err = ResolveRoleSelection(db, firmID, "lawyer")
+	require.NoError(t, err)
+	assert.Equal(t, "lawyer", baseRole)
+	assert.Nil(t, customRoleID)
+
+	// Another firm's role cannot be assigned
+	_, _, err = ResolveRoleSelection(db, "firm-2", CustomRoleOption(role.ID))
+	assert.ErrorIs(t, err, ErrFirmRoleNotFound)
+
+	user := &models.User{FirmID: &firmID, Name: "Acc", Email: "acc@example.com", Role: "staff", CustomRoleID: &role.ID}
+	require.NoError(t, db.Create(user).Error)
+
+	var loaded models.User
+	require.NoError(t, db.Preload("CustomRole").First(&loaded, "id = ?", user.ID).Error)
+	assert.True(t, loaded.Can(models.CapabilityReportsExport))
+	assert.False(t, loaded.Can(models.CapabilitySearch), "staff's own capabilities don't apply")
+
+	// Without the custom role loaded, nothing is granted
+	var unloaded models.User
+	require.NoError(t, db.First(&unloaded, "id = ?", user.ID).Error)
+	assert.False(t, unloaded.Can(models.CapabilityServicesView))
+	assert.False(t, unloaded.Can(models.CapabilitySearch), "the base role doesn't apply either")
+
+	counts, err := CountFirmRoleUsers(db, firmID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, counts[role.ID])
+
+	// Changing the base role moves its users along
+	_, err = UpdateFirmRole(db, firmID, role.ID, FirmRoleInput{Name: "Accountant", BaseRole: "lawyer",
+		Capabilities: []string{models.CapabilityServicesView}})
+	require.NoError(t, err)
+	var updated models.User
+	require.NoError(t, db.First(&updated, "id = ?", user.ID).Error)
+	assert.Equal(t, "lawyer", updated.Role)
+
+	_, err = DeleteFirmRole(db, firmID, role.ID)
+	assert.ErrorIs(t, err, ErrFirmRoleInUse)
+
+	require.NoError(t, db.Model(user).Update("custom_role_id", nil).Error)
+	_, err = DeleteFirmRole(db, firmID, role.ID)
+	assert.NoError(t, err)
+}
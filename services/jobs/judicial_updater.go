@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"law_flow_app_go/models"
+	"law_flow_app_go/services"
 	"law_flow_app_go/services/judicial"
 	"log"
 	"reflect"
@@ -29,6 +30,40 @@ func StartScheduler(database *gorm.DB) {
 		log.Fatalf("[CRON] Error al programar la tarea: %v", err)
 	}
 
+	_, err = c.AddFunc("0 7 * * *", func() {
+		log.Println("[CRON] Enviando alertas de renovación de contratos...")
+		if err := services.SendContractRenewalAlerts(database); err != nil {
+			log.Printf("[CRON] Error enviando alertas de contratos: %v", err)
+		}
+	})
+
+	if err != nil {
+		log.Fatalf("[CRON] Error al programar la tarea: %v", err)
+	}
+
+	// Hourly, so each firm gets its agendas at 07:00 in its own timezone
+	_, err = c.AddFunc("0 * * * *", func() {
+		log.Println("[CRON] Enviando agendas por correo...")
+		if err := services.SendAgendaDigests(database, time.Now()); err != nil {
+			log.Printf("[CRON] Error enviando agendas: %v", err)
+		}
+	})
+
+	if err != nil {
+		log.Fatalf("[CRON] Error al programar la tarea: %v", err)
+	}
+
+	_, err = c.AddFunc("0 3 * * *", func() {
+		log.Println("[CRON] Verificando archivos de documentos en el almacenamiento...")
+		if _, err := services.VerifyDocumentStorage(database, services.Storage, time.Now()); err != nil {
+			log.Printf("[CRON] Error verificando el almacenamiento: %v", err)
+		}
+	})
+
+	if err != nil {
+		log.Fatalf("[CRON] Error al programar la tarea: %v", err)
+	}
+
 	c.Start()
 	log.Println("[CRON] Planificador de tareas iniciado correctamente.")
 }
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Legal hold errors
+var (
+	ErrDocumentUnderLegalHold    = errors.New("document is under legal hold and cannot be deleted")
+	ErrCaseUnderLegalHold        = errors.New("case is under legal hold and its records cannot be deleted")
+	ErrLegalHoldNotFound         = errors.New("legal hold not found")
+	ErrLegalHoldReleased         = errors.New("legal hold has already been released")
+	ErrLegalHoldReasonRequired   = errors.New("a reason is required")
+	ErrLegalHoldInvalidCustodian = errors.New("custodian must be an active member of the firm")
+	ErrLegalHoldDuplicate        = errors.New("an active legal hold already covers this scope")
+	ErrLegalHoldDocumentNotFound = errors.New("document does not belong to this case")
+)
+
+// legalHoldCoversDocument matches active holds on a document or on its whole case.
+// It expects the outer query to expose case_documents.
+const legalHoldCoversDocument = `EXISTS (SELECT 1 FROM legal_holds WHERE legal_holds.released_at IS NULL AND
+	(legal_holds.document_id = case_documents.id OR (legal_holds.document_id IS NULL AND legal_holds.case_id = case_documents.case_id)))`
+
+// LegalHoldInput holds the data needed to place a legal hold
+type LegalHoldInput struct {
+	FirmID      string
+	CaseID      string
+	DocumentID  *string // nil places the hold on the whole case
+	Reason      string
+	CustodianID string
+	PlacedByID  string
+}
+
+// PlaceLegalHold places a hold on a case document or on a whole case and notifies the custodian
+func PlaceLegalHold(db *gorm.DB, input LegalHoldInput) (*models.LegalHold, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, ErrLegalHoldReasonRequired
+	}
+
+	var custodian models.User
+	if err := db.Where("id = ? AND firm_id = ? AND is_active = ? AND role NOT IN ?",
+		input.CustodianID, input.FirmID, true, []string{"client", "external"}).
+		First(&custodian).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLegalHoldInvalidCustodian
+		}
+		return nil, err
+	}
+
+	var caseRecord models.Case
+	if err := db.Select("id", "case_number").First(&caseRecord, "id = ? AND firm_id = ?", input.CaseID, input.FirmID).Error; err != nil {
+		return nil, fmt.Errorf("case not found: %w", err)
+	}
+
+	scope := db.Model(&models.LegalHold{}).Where("case_id = ? AND released_at IS NULL", input.CaseID)
+	if input.DocumentID != nil {
+		var document models.CaseDocument
+		if err := db.Select("id").First(&document, "id = ? AND case_id = ? AND firm_id = ?", *input.DocumentID, input.CaseID, input.FirmID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrLegalHoldDocumentNotFound
+			}
+			return nil, err
+		}
+		scope = scope.Where("document_id = ?", *input.DocumentID)
+	} else {
+		scope = scope.Where("document_id IS NULL")
+	}
+	var existing int64
+	if err := scope.Count(&existing).Error; err != nil {
+		return nil, err
+	}
+	if existing > 0 {
+		return nil, ErrLegalHoldDuplicate
+	}
+
+	hold := models.LegalHold{
+		FirmID:      input.FirmID,
+		CaseID:      input.CaseID,
+		DocumentID:  input.DocumentID,
+		Reason:      reason,
+		CustodianID: custodian.ID,
+		PlacedByID:  input.PlacedByID,
+	}
+	if err := db.Create(&hold).Error; err != nil {
+		return nil, err
+	}
+
+	notifyLegalHoldCustodian(db, &hold, caseRecord.CaseNumber, "placed", reason)
+
+	return &hold, nil
+}
+
+// ReleaseLegalHold ends a hold, recording who released it and why, and notifies the custodian.
+// The hold itself is kept so the compliance report shows its full lifecycle.
+func ReleaseLegalHold(db *gorm.DB, firmID, holdID, releasedByID, reason string, now time.Time) (*models.LegalHold, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrLegalHoldReasonRequired
+	}
+
+	var hold models.LegalHold
+	if err := db.Preload("Case").First(&hold, "id = ? AND firm_id = ?", holdID, firmID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLegalHoldNotFound
+		}
+		return nil, err
+	}
+	if !hold.IsActive() {
+		return nil, ErrLegalHoldReleased
+	}
+
+	if err := db.Model(&hold).Updates(map[string]interface{}{
+		"released_at":    now,
+		"released_by_id": releasedByID,
+		"release_reason": reason,
+	}).Error; err != nil {
+		return nil, err
+	}
+	hold.ReleasedAt = &now
+	hold.ReleasedByID = &releasedByID
+	hold.ReleaseReason = &reason
+
+	caseNumber := ""
+	if hold.Case != nil {
+		caseNumber = hold.Case.CaseNumber
+	}
+	notifyLegalHoldCustodian(db, &hold, caseNumber, "released", reason)
+
+	return &hold, nil
+}
+
+// notifyLegalHoldCustodian sends an in-app notification to the hold's custodian, in their
+// language. The event is "placed" or "released".
+func notifyLegalHoldCustodian(db *gorm.DB, hold *models.LegalHold, caseNumber, event, reason string) {
+	lang := "es"
+	var custodian models.User
+	if err := db.Select("id", "language").First(&custodian, "id = ?", hold.CustodianID).Error; err == nil && custodian.Language != "" {
+		lang = custodian.Language
+	}
+	args := map[string]interface{}{"case": caseNumber, "reason": reason}
+
+	custodianID := hold.CustodianID
+	caseID := hold.CaseID
+	notification := &models.Notification{
+		FirmID:  hold.FirmID,
+		UserID:  &custodianID,
+		CaseID:  &caseID,
+		Type:    models.NotificationTypeLegalHold,
+		Title:   i18n.Translate(lang, "cases.legal_hold.notification."+event+"_title", args),
+		Message: i18n.Translate(lang, "cases.legal_hold.notification."+event+"_message", args),
+		LinkURL: "/cases/" + hold.CaseID,
+	}
+	if err := NewNotificationService(db).CreateNotification(notification); err != nil {
+		// The hold is in force regardless; the custodian can still see it on the case
+		log.Printf("Failed to notify custodian of legal hold %s on case %s: %v", hold.ID, caseNumber, err)
+	}
+}
+
+// IsDocumentUnderLegalHold reports whether an active hold covers the document, either
+// directly or through a hold on its whole case
+func IsDocumentUnderLegalHold(db *gorm.DB, documentID string) (bool, error) {
+	var count int64
+	err := db.Model(&models.CaseDocument{}).
+		Where("case_documents.id = ?", documentID).
+		Where(legalHoldCoversDocument).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsCaseUnderLegalHold reports whether the whole case is under an active hold
+func IsCaseUnderLegalHold(db *gorm.DB, caseID string) (bool, error) {
+	var count int64
+	err := db.Model(&models.LegalHold{}).
+		Where("case_id = ? AND document_id IS NULL AND released_at IS NULL", caseID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetHeldDocumentIDs returns the IDs of the case's documents covered by an active hold
+func GetHeldDocumentIDs(db *gorm.DB, caseID string) (map[string]bool, error) {
+	var ids []string
+	if err := db.Model(&models.CaseDocument{}).
+		Where("case_documents.case_id = ?", caseID).
+		Where(legalHoldCoversDocument).
+		Pluck("case_documents.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	held := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		held[id] = true
+	}
+	return held, nil
+}
+
+// GetCaseLegalHolds returns every hold placed on a case, active ones first
+func GetCaseLegalHolds(db *gorm.DB, firmID, caseID string) ([]models.LegalHold, error) {
+	var holds []models.LegalHold
+	err := db.Preload("Document").Preload("Custodian").Preload("PlacedBy").Preload("ReleasedBy").
+		Where("firm_id = ? AND case_id = ?", firmID, caseID).
+		Order("released_at IS NOT NULL, created_at DESC").
+		Find(&holds).Error
+	return holds, err
+}
+
+// GetFirmLegalHolds returns the firm's holds for the compliance report, optionally filtered by status
+func GetFirmLegalHolds(db *gorm.DB, firmID, status string, page, limit int) ([]models.LegalHold, int64, error) {
+	var holds []models.LegalHold
+	var total int64
+
+	query := db.Model(&models.LegalHold{}).Where("firm_id = ?", firmID)
+	switch status {
+	case models.LegalHoldStatusActive:
+		query = query.Where("released_at IS NULL")
+	case models.LegalHoldStatusReleased:
+		query = query.Where("released_at IS NOT NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count legal holds: %w", err)
+	}
+
+	// Documents are preloaded unscoped so holds on since-deleted documents still show their name
+	if err := query.Preload("Case").
+		Preload("Document", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Custodian").Preload("PlacedBy").Preload("ReleasedBy").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&holds).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get legal holds: %w", err)
+	}
+
+	return holds, total, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type legalHoldFixture struct {
+	db        *gorm.DB
+	firm      *models.Firm
+	admin     *models.User
+	lawyer    *models.User
+	client    *models.User
+	caseOne   *models.Case
+	caseTwo   *models.Case
+	heldDoc   *models.CaseDocument
+	freeDoc   *models.CaseDocument
+	otherCase *models.CaseDocument
+}
+
+func setupLegalHoldTestDB(t *testing.T) *legalHoldFixture {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Firm{}, &models.User{}, &models.Case{},
+		&models.CaseDocument{}, &models.Notification{}, &models.LegalHold{}))
+
+	f := &legalHoldFixture{db: db}
+	f.firm = &models.Firm{Name: "Hold Firm", BillingEmail: "billing@hold.com"}
+	db.Create(f.firm)
+	f.admin = &models.User{FirmID: &f.firm.ID, Name: "Admin", Email: "admin@hold.com", Role: "admin", IsActive: true}
+	db.Create(f.admin)
+	f.lawyer = &models.User{FirmID: &f.firm.ID, Name: "Custodian", Email: "custodian@hold.com", Role: "lawyer", IsActive: true, Language: "en"}
+	db.Create(f.lawyer)
+	f.client = &models.User{FirmID: &f.firm.ID, Name: "Client", Email: "client@hold.com", Role: "client", IsActive: true}
+	db.Create(f.client)
+
+	f.caseOne = &models.Case{FirmID: f.firm.ID, ClientID: f.client.ID, CaseNumber: "HOLD-1", CaseType: "Civil",
+		Description: "Test", Status: models.CaseStatusOpen, OpenedAt: time.Now()}
+	require.NoError(t, db.Create(f.caseOne).Error)
+	f.caseTwo = &models.Case{FirmID: f.firm.ID, ClientID: f.client.ID, CaseNumber: "HOLD-2", CaseType: "Civil",
+		Description: "Test", Status: models.CaseStatusOpen, OpenedAt: time.Now()}
+	require.NoError(t, db.Create(f.caseTwo).Error)
+
+	f.heldDoc = &models.CaseDocument{FirmID: f.firm.ID, CaseID: &f.caseOne.ID, FileName: "a.pdf", FileOriginalName: "a.pdf", FilePath: "a.pdf", FileSize: 1}
+	f.freeDoc = &models.CaseDocument{FirmID: f.firm.ID, CaseID: &f.caseOne.ID, FileName: "b.pdf", FileOriginalName: "b.pdf", FilePath: "b.pdf", FileSize: 1}
+	f.otherCase = &models.CaseDocument{FirmID: f.firm.ID, CaseID: &f.caseTwo.ID, FileName: "c.pdf", FileOriginalName: "c.pdf", FilePath: "c.pdf", FileSize: 1}
+	for _, doc := range []*models.CaseDocument{f.heldDoc, f.freeDoc, f.otherCase} {
+		require.NoError(t, db.Create(doc).Error)
+	}
+
+	return f
+}
+
+func TestPlaceLegalHold(t *testing.T) {
+	f := setupLegalHoldTestDB(t)
+	i18n.Load()
+
+	input := LegalHoldInput{
+		FirmID: f.firm.ID, CaseID: f.caseOne.ID, DocumentID: &f.heldDoc.ID,
+		Reason: "  Pending litigation  ", CustodianID: f.lawyer.ID, PlacedByID: f.admin.ID,
+	}
+	hold, err := PlaceLegalHold(f.db, input)
+	require.NoError(t, err)
+	assert.Equal(t, "Pending litigation", hold.Reason)
+	assert.True(t, hold.IsActive())
+	assert.False(t, hold.IsCaseWide())
+
+	// The custodian is notified in their language
+	var notifications []models.Notification
+	f.db.Where("user_id = ? AND type = ?", f.lawyer.ID, models.NotificationTypeLegalHold).Find(&notifications)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "Legal hold placed: HOLD-1", notifications[0].Title)
+	assert.Equal(t, "You are the custodian of a legal hold on case HOLD-1: Pending litigation", notifications[0].Message)
+
+	// The same scope cannot be held twice
+	_, err = PlaceLegalHold(f.db, input)
+	assert.ErrorIs(t, err, ErrLegalHoldDuplicate)
+
+	// A document from another case cannot be held through this one
+	input.DocumentID = &f.otherCase.ID
+	_, err = PlaceLegalHold(f.db, input)
+	assert.ErrorIs(t, err, ErrLegalHoldDocumentNotFound)
+
+	// A reason is mandatory
+	input.DocumentID = nil
+	input.Reason = " "
+	_, err = PlaceLegalHold(f.db, input)
+	assert.ErrorIs(t, err, ErrLegalHoldReasonRequired)
+
+	// Clients cannot be custodians
+	input.Reason = "Regulatory inquiry"
+	input.CustodianID = f.client.ID
+	_, err = PlaceLegalHold(f.db, input)
+	assert.ErrorIs(t, err, ErrLegalHoldInvalidCustodian)
+
+	// A case-wide hold may coexist with a document hold on the same case
+	input.CustodianID = f.lawyer.ID
+	caseHold, err := PlaceLegalHold(f.db, input)
+	require.NoError(t, err)
+	assert.True(t, caseHold.IsCaseWide())
+}
+
+func TestLegalHoldCoverage(t *testing.T) {
+	f := setupLegalHoldTestDB(t)
+
+	_, err := PlaceLegalHold(f.db, LegalHoldInput{
+		FirmID: f.firm.ID, CaseID: f.caseOne.ID, DocumentID: &f.heldDoc.ID,
+		Reason: "Pending litigation", CustodianID: f.lawyer.ID, PlacedByID: f.admin.ID,
+	})
+	require.NoError(t, err)
+
+	held, err := IsDocumentUnderLegalHold(f.db, f.heldDoc.ID)
+	require.NoError(t, err)
+	assert.True(t, held)
+	held, err = IsDocumentUnderLegalHold(f.db, f.freeDoc.ID)
+	require.NoError(t, err)
+	assert.False(t, held)
+	caseHeld, err := IsCaseUnderLegalHold(f.db, f.caseOne.ID)
+	require.NoError(t, err)
+	assert.False(t, caseHeld, "a document hold does not hold the whole case")
+
+	ids, err := GetHeldDocumentIDs(f.db, f.caseOne.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{f.heldDoc.ID: true}, ids)
+
+	// A case-wide hold covers every document of the case, and only that case
+	caseHold, err := PlaceLegalHold(f.db, LegalHoldInput{
+		FirmID: f.firm.ID, CaseID: f.caseOne.ID,
+		Reason: "Preservation letter", CustodianID: f.lawyer.ID, PlacedByID: f.admin.ID,
+	})
+	require.NoError(t, err)
+	ids, err = GetHeldDocumentIDs(f.db, f.caseOne.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{f.heldDoc.ID: true, f.freeDoc.ID: true}, ids)
+	ids, err = GetHeldDocumentIDs(f.db, f.caseTwo.ID)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// Releasing the case-wide hold leaves the document hold in force
+	_, err = ReleaseLegalHold(f.db, f.firm.ID, caseHold.ID, f.admin.ID, "Matter settled", time.Now())
+	require.NoError(t, err)
+	held, err = IsDocumentUnderLegalHold(f.db, f.freeDoc.ID)
+	require.NoError(t, err)
+	assert.False(t, held)
+	held, err = IsDocumentUnderLegalHold(f.db, f.heldDoc.ID)
+	require.NoError(t, err)
+	assert.True(t, held)
+}
+
+func TestReleaseLegalHold(t *testing.T) {
+	f := setupLegalHoldTestDB(t)
+	now := time.Now()
+
+	hold, err := PlaceLegalHold(f.db, LegalHoldInput{
+		FirmID: f.firm.ID, CaseID: f.caseOne.ID,
+		Reason: "Pending litigation", CustodianID: f.lawyer.ID, PlacedByID: f.admin.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = ReleaseLegalHold(f.db, f.firm.ID, hold.ID, f.admin.ID, "", now)
+	assert.ErrorIs(t, err, ErrLegalHoldReasonRequired)
+	_, err = ReleaseLegalHold(f.db, "other-firm", hold.ID, f.admin.ID, "Settled", now)
+	assert.ErrorIs(t, err, ErrLegalHoldNotFound)
+
+	released, err := ReleaseLegalHold(f.db, f.firm.ID, hold.ID, f.admin.ID, "Settled", now)
+	require.NoError(t, err)
+	assert.False(t, released.IsActive())
+	assert.Equal(t, models.LegalHoldStatusReleased, released.Status())
+	require.NotNil(t, released.ReleaseReason)
+	assert.Equal(t, "Settled", *released.ReleaseReason)
+
+	_, err = ReleaseLegalHold(f.db, f.firm.ID, hold.ID, f.admin.ID, "Settled", now)
+	assert.ErrorIs(t, err, ErrLegalHoldReleased)
+
+	// Place and release both notify the custodian
+	var notifications int64
+	f.db.Model(&models.Notification{}).Where("user_id = ? AND type = ?", f.lawyer.ID, models.NotificationTypeLegalHold).Count(&notifications)
+	assert.Equal(t, int64(2), notifications)
+
+	// Released holds stay on record for the compliance report
+	holds, total, err := GetFirmLegalHolds(f.db, f.firm.ID, models.LegalHoldStatusReleased, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, holds, 1)
+	require.NotNil(t, holds[0].ReleasedBy)
+	assert.Equal(t, f.admin.ID, holds[0].ReleasedBy.ID)
+	_, total, err = GetFirmLegalHolds(f.db, f.firm.ID, models.LegalHoldStatusActive, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
@@ -0,0 +1,102 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Lookup errors shared by all providers
+var (
+	// ErrNotFound means the registry answered but has no entry for the tax ID
+	ErrNotFound = errors.New("no registry entry for tax id")
+	// ErrUnavailable means the registry could not be reached or answered with an error
+	ErrUnavailable = errors.New("registry unavailable")
+)
+
+// Provider defines the interface for country-specific public registries
+type Provider interface {
+	// Name identifies the registry (e.g. "RUES") so prefilled data can cite its source
+	Name() string
+
+	// LookupByTaxID returns the registered company or person for a normalized tax ID.
+	// It returns ErrNotFound when there is no entry and ErrUnavailable when the registry is down.
+	LookupByTaxID(taxID string) (*Record, error)
+}
+
+// Record normalizes the data returned by a registry. Fields the registry
+// does not provide are left empty.
+type Record struct {
+	TaxID               string `json:"tax_id"`
+	VerificationDigit   string `json:"verification_digit,omitempty"`
+	Name                string `json:"name"`
+	EntityType          string `json:"entity_type,omitempty"` // e.g. "SOCIEDAD POR ACCIONES SIMPLIFICADA"
+	Status              string `json:"status,omitempty"`      // e.g. "ACTIVA", "CANCELADA"
+	Address             string `json:"address,omitempty"`
+	City                string `json:"city,omitempty"`
+	Department          string `json:"department,omitempty"`
+	Email               string `json:"email,omitempty"`
+	Phone               string `json:"phone,omitempty"`
+	LegalRepresentative string `json:"legal_representative,omitempty"`
+	Source              string `json:"source"`
+}
+
+// BaseService provides common functionality like HTTP client
+type BaseService struct {
+	client *http.Client
+}
+
+// NewBaseService creates a configured base service. The timeout is short because
+// lookups run while a user waits on a form.
+func NewBaseService() BaseService {
+	return BaseService{
+		client: &http.Client{
+			Timeout: 8 * time.Second,
+		},
+	}
+}
+
+var providers = make(map[string]Provider)
+
+// RegisterProvider allows manual registration of a provider (useful for testing)
+func RegisterProvider(countryCode string, p Provider) {
+	if p == nil {
+		delete(providers, countryCode)
+		return
+	}
+	providers[countryCode] = p
+}
+
+// GetProvider returns the registry implementation for a country code or name
+func GetProvider(countryCode string) (Provider, error) {
+	// Check registry first (for mocks)
+	if p, ok := providers[countryCode]; ok {
+		return p, nil
+	}
+
+	switch countryCode {
+	case "COL", "CO", "Colombia", "colombia":
+		return NewRUESService(), nil
+	default:
+		return nil, fmt.Errorf("registry provider not implemented for country: %s", countryCode)
+	}
+}
+
+// NormalizeTaxID strips separators and a trailing verification digit written after a dash
+// ("900.123.456-7" becomes "900123456"). It returns an empty string when no digits remain.
+func NormalizeTaxID(taxID string) string {
+	taxID = strings.TrimSpace(taxID)
+	if i := strings.LastIndex(taxID, "-"); i > 0 {
+		taxID = taxID[:i]
+	}
+
+	var b strings.Builder
+	for _, r := range taxID {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubProvider struct{}
+
+func (stubProvider) Name() string                                { return "STUB" }
+func (stubProvider) LookupByTaxID(taxID string) (*Record, error) { return &Record{TaxID: taxID}, nil }
+
+func TestGetProvider(t *testing.T) {
+	t.Run("Default Colombia provider", func(t *testing.T) {
+		for _, code := range []string{"COL", "CO", "Colombia"} {
+			p, err := GetProvider(code)
+			assert.NoError(t, err)
+			assert.IsType(t, &RUESService{}, p)
+		}
+	})
+
+	t.Run("Unsupported country", func(t *testing.T) {
+		p, err := GetProvider("USA")
+		assert.Error(t, err)
+		assert.Nil(t, p)
+		assert.Contains(t, err.Error(), "registry provider not implemented")
+	})
+
+	t.Run("Registered provider", func(t *testing.T) {
+		RegisterProvider("MOCK", stubProvider{})
+		p, err := GetProvider("MOCK")
+		assert.NoError(t, err)
+		assert.Equal(t, "STUB", p.Name())
+
+		RegisterProvider("MOCK", nil)
+		_, err = GetProvider("MOCK")
+		assert.Error(t, err)
+	})
+}
+
+func TestNormalizeTaxID(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"900123456", "900123456"},
+		{"900.123.456-7", "900123456"},
+		{" 900 123 456 ", "900123456"},
+		{"1.020.304.050", "1020304050"},
+		{"-", ""},
+		{"abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expect, NormalizeTaxID(tt.input))
+		})
+	}
+}
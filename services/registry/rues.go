@@ -0,0 +1,107 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RUESBaseURL is the consultation API of Colombia's Registro Único Empresarial y Social
+var RUESBaseURL = "https://ruesapi.rues.org.co/api"
+
+// RUESService implements Provider for Colombia
+type RUESService struct {
+	BaseService
+}
+
+// NewRUESService creates a new instance
+func NewRUESService() *RUESService {
+	return &RUESService{
+		BaseService: NewBaseService(),
+	}
+}
+
+// === RUES Internal Structs ===
+
+type ruesSearchResponse struct {
+	Registros []ruesRecord `json:"registros"`
+}
+
+type ruesRecord struct {
+	NIT                  string `json:"nit"`
+	DV                   string `json:"dv"`
+	RazonSocial          string `json:"razon_social"`
+	OrganizacionJuridica string `json:"organizacion_juridica"`
+	EstadoMatricula      string `json:"estado_matricula"`
+	DireccionComercial   string `json:"direccion_comercial"`
+	MunicipioComercial   string `json:"municipio_comercial"`
+	DptoComercial        string `json:"dpto_comercial"`
+	CorreoComercial      string `json:"correo_comercial"`
+	TelefonoComercial    string `json:"telefono_comercial"`
+	RepresentanteLegal   string `json:"representante_legal"`
+}
+
+// Name implements Provider
+func (s *RUESService) Name() string {
+	return "RUES"
+}
+
+// LookupByTaxID implements Provider. A NIT can have several chamber-of-commerce
+// registrations; active ones are preferred.
+func (s *RUESService) LookupByTaxID(taxID string) (*Record, error) {
+	params := url.Values{}
+	params.Add("nit", taxID)
+
+	reqURL := fmt.Sprintf("%s/ConsultasRUES/BusquedaNIT?%s", RUESBaseURL, params.Encode())
+
+	resp, err := s.client.Get(reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: API returned status: %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	var searchResp ruesSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrUnavailable, err)
+	}
+
+	if len(searchResp.Registros) == 0 {
+		return nil, ErrNotFound
+	}
+
+	selected := searchResp.Registros[0]
+	for _, reg := range searchResp.Registros {
+		if strings.EqualFold(strings.TrimSpace(reg.EstadoMatricula), "ACTIVA") {
+			selected = reg
+			break
+		}
+	}
+
+	nit := strings.TrimSpace(selected.NIT)
+	if nit == "" {
+		nit = taxID
+	}
+	return &Record{
+		TaxID:               nit,
+		VerificationDigit:   strings.TrimSpace(selected.DV),
+		Name:                strings.TrimSpace(selected.RazonSocial),
+		EntityType:          strings.TrimSpace(selected.OrganizacionJuridica),
+		Status:              strings.TrimSpace(selected.EstadoMatricula),
+		Address:             strings.TrimSpace(selected.DireccionComercial),
+		City:                strings.TrimSpace(selected.MunicipioComercial),
+		Department:          strings.TrimSpace(selected.DptoComercial),
+		Email:               strings.ToLower(strings.TrimSpace(selected.CorreoComercial)),
+		Phone:               strings.TrimSpace(selected.TelefonoComercial),
+		LegalRepresentative: strings.TrimSpace(selected.RepresentanteLegal),
+		Source:              s.Name(),
+	}, nil
+}
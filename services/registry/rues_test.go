@@ -0,0 +1,86 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRUESLookupByTaxID(t *testing.T) {
+	originalURL := RUESBaseURL
+	defer func() { RUESBaseURL = originalURL }()
+
+	t.Run("Prefers the active registration", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Contains(t, r.URL.Path, "/ConsultasRUES/BusquedaNIT")
+			assert.Equal(t, "900123456", r.URL.Query().Get("nit"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{
+				"registros": [
+					{"nit": "900123456", "razon_social": "OLD NAME SAS", "estado_matricula": "CANCELADA"},
+					{
+						"nit": "900123456",
+						"dv": "7",
+						"razon_social": " ACME LEGAL SAS ",
+						"organizacion_juridica": "SOCIEDAD POR ACCIONES SIMPLIFICADA",
+						"estado_matricula": "ACTIVA",
+						"direccion_comercial": "CL 10 20 30",
+						"municipio_comercial": "BOGOTA",
+						"dpto_comercial": "BOGOTA D.C.",
+						"correo_comercial": "Contacto@Acme.CO",
+						"telefono_comercial": "6015551234",
+						"representante_legal": "JANE DOE"
+					}
+				]
+			}`)
+		}))
+		defer server.Close()
+		RUESBaseURL = server.URL
+
+		record, err := NewRUESService().LookupByTaxID("900123456")
+		assert.NoError(t, err)
+		assert.Equal(t, "ACME LEGAL SAS", record.Name)
+		assert.Equal(t, "7", record.VerificationDigit)
+		assert.Equal(t, "ACTIVA", record.Status)
+		assert.Equal(t, "contacto@acme.co", record.Email)
+		assert.Equal(t, "BOGOTA", record.City)
+		assert.Equal(t, "JANE DOE", record.LegalRepresentative)
+		assert.Equal(t, "RUES", record.Source)
+	})
+
+	t.Run("No registrations", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"registros": []}`)
+		}))
+		defer server.Close()
+		RUESBaseURL = server.URL
+
+		_, err := NewRUESService().LookupByTaxID("1")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("Registry error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+		RUESBaseURL = server.URL
+
+		_, err := NewRUESService().LookupByTaxID("900123456")
+		assert.True(t, errors.Is(err, ErrUnavailable))
+	})
+
+	t.Run("Registry unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		RUESBaseURL = server.URL
+		server.Close()
+
+		_, err := NewRUESService().LookupByTaxID("900123456")
+		assert.True(t, errors.Is(err, ErrUnavailable))
+	})
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/registry"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Registry lookup errors
+var (
+	ErrRegistryLookupDisabled    = errors.New("registry lookup is not enabled for this firm")
+	ErrRegistryLookupUnsupported = errors.New("no public registry connector for the firm's country")
+	ErrRegistryInvalidTaxID      = errors.New("invalid tax id")
+	ErrRegistryNotFound          = errors.New("no registry entry for tax id")
+	ErrRegistryUnavailable       = errors.New("registry is unavailable and no cached entry exists")
+)
+
+const (
+	// RegistryCacheTTL is how long a registry entry is served from cache before refreshing
+	RegistryCacheTTL = 7 * 24 * time.Hour
+	// RegistryNotFoundCacheTTL is how long a "no entry" answer is remembered
+	RegistryNotFoundCacheTTL = 24 * time.Hour
+)
+
+// RegistryLookupResult is a registry entry along with where it came from
+type RegistryLookupResult struct {
+	Record    registry.Record
+	FetchedAt time.Time
+	Cached    bool
+	Stale     bool // The registry was down and an expired cache entry was served instead
+}
+
+// firmRegistryProvider returns the registry connector for a firm's country
+func firmRegistryProvider(db *gorm.DB, firmID string) (registry.Provider, string, error) {
+	countryCode := firmCountryCode(db, firmID)
+	if countryCode == "" {
+		return nil, "", ErrRegistryLookupUnsupported
+	}
+	provider, err := registry.GetProvider(countryCode)
+	if err != nil {
+		return nil, "", ErrRegistryLookupUnsupported
+	}
+	return provider, countryCode, nil
+}
+
+// RegistryLookupAvailable reports whether forms should offer registry prefill for the firm
+func RegistryLookupAvailable(db *gorm.DB, firm *models.Firm) bool {
+	if firm == nil || !firm.RegistryLookupEnabled {
+		return false
+	}
+	_, _, err := firmRegistryProvider(db, firm.ID)
+	return err == nil
+}
+
+// LookupRegistry fetches the registered company or person for a tax ID from the firm's national
+// registry. Answers are cached; when the registry is down an expired cache entry is served
+// (marked Stale) so forms can still be prefilled, and ErrRegistryUnavailable is returned otherwise.
+func LookupRegistry(db *gorm.DB, firm *models.Firm, taxID string, now time.Time) (*RegistryLookupResult, error) {
+	if firm == nil || !firm.RegistryLookupEnabled {
+		return nil, ErrRegistryLookupDisabled
+	}
+	provider, countryCode, err := firmRegistryProvider(db, firm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := registry.NormalizeTaxID(taxID)
+	if len(normalized) < 5 || len(normalized) > 15 {
+		return nil, ErrRegistryInvalidTaxID
+	}
+
+	var cached models.RegistryLookup
+	hasCache := db.Where("country = ? AND tax_id = ?", countryCode, normalized).First(&cached).Error == nil
+	if hasCache {
+		age := now.Sub(cached.FetchedAt)
+		if !cached.Found && age < RegistryNotFoundCacheTTL {
+			return nil, ErrRegistryNotFound
+		}
+		if cached.Found && age < RegistryCacheTTL {
+			if result, err := registryResultFromCache(cached, false); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	record, err := provider.LookupByTaxID(normalized)
+	if errors.Is(err, registry.ErrNotFound) {
+		saveRegistryLookup(db, cached, countryCode, normalized, provider.Name(), nil, now)
+		return nil, ErrRegistryNotFound
+	}
+	if err != nil {
+		log.Printf("Registry lookup via %s failed for %s: %v", provider.Name(), normalized, err)
+		if hasCache && cached.Found {
+			if result, cacheErr := registryResultFromCache(cached, true); cacheErr == nil {
+				return result, nil
+			}
+		}
+		return nil, ErrRegistryUnavailable
+	}
+
+	saveRegistryLookup(db, cached, countryCode, normalized, provider.Name(), record, now)
+	return &RegistryLookupResult{Record: *record, FetchedAt: now}, nil
+}
+
+// registryResultFromCache decodes a cached registry entry
+func registryResultFromCache(cached models.RegistryLookup, stale bool) (*RegistryLookupResult, error) {
+	var record registry.Record
+	if err := json.Unmarshal([]byte(cached.Data), &record); err != nil {
+		return nil, err
+	}
+	return &RegistryLookupResult{Record: record, FetchedAt: cached.FetchedAt, Cached: true, Stale: stale}, nil
+}
+
+// saveRegistryLookup creates or refreshes the cache entry for a tax ID. A nil record caches a "no entry" answer.
+// Cache write failures are logged only; the lookup itself already succeeded.
+func saveRegistryLookup(db *gorm.DB, entry models.RegistryLookup, countryCode, taxID, source string, record *registry.Record, now time.Time) {
+	entry.Country = countryCode
+	entry.TaxID = taxID
+	entry.Source = source
+	entry.Found = record != nil
+	entry.Data = ""
+	entry.FetchedAt = now
+	if record != nil {
+		data, err := json.Marshal(record)
+		if err != nil {
+			log.Printf("Error encoding registry entry for %s: %v", taxID, err)
+			return
+		}
+		entry.Data = string(data)
+	}
+	if err := db.Save(&entry).Error; err != nil {
+		log.Printf("Error caching registry entry for %s: %v", taxID, err)
+	}
+}
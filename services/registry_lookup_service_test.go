@@ -0,0 +1,136 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/registry"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// fakeRegistry is a registry.Provider whose answer can be switched between calls
+type fakeRegistry struct {
+	record *registry.Record
+	err    error
+	calls  int
+}
+
+func (f *fakeRegistry) Name() string { return "FAKE" }
+
+func (f *fakeRegistry) LookupByTaxID(taxID string) (*registry.Record, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	record := *f.record
+	record.TaxID = taxID
+	return &record, nil
+}
+
+func setupRegistryLookupTestDB(t *testing.T) (*gorm.DB, *models.Firm, *fakeRegistry) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Country{}, &models.Firm{}, &models.RegistryLookup{}))
+
+	country := &models.Country{Code: "ZZZ", Name: "Testland", IsActive: true}
+	db.Create(country)
+	firm := &models.Firm{Name: "Registry Firm", CountryID: country.ID, BillingEmail: "billing@test.com", RegistryLookupEnabled: true}
+	db.Create(firm)
+
+	provider := &fakeRegistry{record: &registry.Record{Name: "ACME SAS", Status: "ACTIVA", Source: "FAKE"}}
+	registry.RegisterProvider("ZZZ", provider)
+	t.Cleanup(func() { registry.RegisterProvider("ZZZ", nil) })
+
+	return db, firm, provider
+}
+
+func TestLookupRegistry(t *testing.T) {
+	now := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Caches registry answers", func(t *testing.T) {
+		db, firm, provider := setupRegistryLookupTestDB(t)
+
+		result, err := LookupRegistry(db, firm, "900.123.456-7", now)
+		require.NoError(t, err)
+		assert.Equal(t, "ACME SAS", result.Record.Name)
+		assert.Equal(t, "900123456", result.Record.TaxID)
+		assert.False(t, result.Cached)
+
+		result, err = LookupRegistry(db, firm, "900123456", now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, result.Cached)
+		assert.False(t, result.Stale)
+		assert.Equal(t, 1, provider.calls)
+
+		// Expired entries are refreshed
+		provider.record.Name = "ACME RENAMED SAS"
+		result, err = LookupRegistry(db, firm, "900123456", now.Add(RegistryCacheTTL+time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, "ACME RENAMED SAS", result.Record.Name)
+		assert.Equal(t, 2, provider.calls)
+
+		var count int64
+		db.Model(&models.RegistryLookup{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Serves stale cache when the registry is down", func(t *testing.T) {
+		db, firm, provider := setupRegistryLookupTestDB(t)
+
+		_, err := LookupRegistry(db, firm, "900123456", now)
+		require.NoError(t, err)
+
+		provider.err = registry.ErrUnavailable
+		result, err := LookupRegistry(db, firm, "900123456", now.Add(RegistryCacheTTL+time.Hour))
+		require.NoError(t, err)
+		assert.True(t, result.Stale)
+		assert.Equal(t, "ACME SAS", result.Record.Name)
+		assert.Equal(t, now, result.FetchedAt.UTC())
+
+		_, err = LookupRegistry(db, firm, "800999111", now)
+		assert.ErrorIs(t, err, ErrRegistryUnavailable)
+	})
+
+	t.Run("Remembers missing entries for a day", func(t *testing.T) {
+		db, firm, provider := setupRegistryLookupTestDB(t)
+		provider.err = registry.ErrNotFound
+
+		_, err := LookupRegistry(db, firm, "800999111", now)
+		assert.ErrorIs(t, err, ErrRegistryNotFound)
+		_, err = LookupRegistry(db, firm, "800999111", now.Add(time.Hour))
+		assert.ErrorIs(t, err, ErrRegistryNotFound)
+		assert.Equal(t, 1, provider.calls)
+
+		provider.err = nil
+		result, err := LookupRegistry(db, firm, "800999111", now.Add(RegistryNotFoundCacheTTL+time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, "ACME SAS", result.Record.Name)
+	})
+
+	t.Run("Requires per-firm enablement and a supported country", func(t *testing.T) {
+		db, firm, provider := setupRegistryLookupTestDB(t)
+		assert.True(t, RegistryLookupAvailable(db, firm))
+
+		_, err := LookupRegistry(db, firm, "12", now)
+		assert.ErrorIs(t, err, ErrRegistryInvalidTaxID)
+
+		firm.RegistryLookupEnabled = false
+		assert.False(t, RegistryLookupAvailable(db, firm))
+		_, err = LookupRegistry(db, firm, "900123456", now)
+		assert.ErrorIs(t, err, ErrRegistryLookupDisabled)
+
+		other := &models.Country{Code: "YYY", Name: "Nowhere", IsActive: true}
+		db.Create(other)
+		unsupported := &models.Firm{Name: "Elsewhere", CountryID: other.ID, BillingEmail: "b@test.com", RegistryLookupEnabled: true}
+		db.Create(unsupported)
+		assert.False(t, RegistryLookupAvailable(db, unsupported))
+		_, err = LookupRegistry(db, unsupported, "900123456", now)
+		assert.ErrorIs(t, err, ErrRegistryLookupUnsupported)
+
+		assert.Equal(t, 0, provider.calls)
+	})
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"law_flow_app_go/models"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Seat change actions reported to SeatChangeHook
+const (
+	SeatActionClaimed  = "claimed"
+	SeatActionReleased = "released"
+)
+
+// SeatChangeEvent describes a billable seat being claimed or released
+type SeatChangeEvent struct {
+	FirmID      string
+	UserID      string
+	Action      string
+	EffectiveAt time.Time
+	// ProrationFactor is the fraction (0-1) of the current billing period
+	// remaining at EffectiveAt, for prorated charges or credits
+	ProrationFactor float64
+}
+
+// SeatChangeHook is called every time a seat is claimed or released.
+// It is a no-op until the payment integration (Stripe) is in place.
+var SeatChangeHook = func(db *gorm.DB, event SeatChangeEvent) {}
+
+// SeatSummary contains seat usage for the billing tab
+type SeatSummary struct {
+	Active         int // Active billable users
+	PendingRelease int // Deactivated users whose seat is held until period end
+	InUse          int // Active + PendingRelease
+	Paid           int // Effective user limit (-1 = unlimited)
+}
+
+// GetSeatSummary returns seats in use vs paid for a firm
+func GetSeatSummary(db *gorm.DB, firmID string, plan *models.Plan) (*SeatSummary, error) {
+	var active, pending int64
+	if err := db.Model(&models.User{}).
+		Where("firm_id = ? AND role IN (?, ?, ?) AND is_active = ?", firmID, "admin", "lawyer", "staff", true).
+		Count(&active).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&models.User{}).
+		Where("firm_id = ? AND role IN (?, ?, ?) AND is_active = ? AND seat_held_until > ?",
+			firmID, "admin", "lawyer", "staff", false, time.Now()).
+		Count(&pending).Error; err != nil {
+		return nil, err
+	}
+
+	return &SeatSummary{
+		Active:         int(active),
+		PendingRelease: int(pending),
+		InUse:          int(active + pending),
+		Paid:           GetEffectiveUserLimit(db, firmID, plan),
+	}, nil
+}
+
+// SeatReleaseDate returns when a seat given up at `at` stops being billed.
+// Paid subscriptions keep the seat until the current period ends; trials
+// and subscriptions without a billing period release it immediately.
+func SeatReleaseDate(subscription *models.FirmSubscription, at time.Time) time.Time {
+	if subscription == nil || subscription.IsTrialing() || subscription.CurrentPeriodEnd == nil {
+		return at
+	}
+	if subscription.CurrentPeriodEnd.After(at) {
+		return *subscription.CurrentPeriodEnd
+	}
+	return at
+}
+
+// ProrationFactor returns the fraction of the current billing period remaining at `at`
+func ProrationFactor(subscription *models.FirmSubscription, at time.Time) float64 {
+	if subscription == nil || subscription.CurrentPeriodStart == nil || subscription.CurrentPeriodEnd == nil {
+		return 0
+	}
+	total := subscription.CurrentPeriodEnd.Sub(*subscription.CurrentPeriodStart)
+	if total <= 0 {
+		return 0
+	}
+	remaining := subscription.CurrentPeriodEnd.Sub(at)
+	switch {
+	case remaining <= 0:
+		return 0
+	case remaining >= total:
+		return 1
+	default:
+		return float64(remaining) / float64(total)
+	}
+}
+
+// CanReactivateUser checks if a deactivated user can be reactivated.
+// A user still holding its seat can always come back; otherwise a new seat is needed.
+func CanReactivateUser(db *gorm.DB, firmID string, user *models.User) (*LimitCheckResult, error) {
+	if !user.IsBillable() || user.HoldsSeat(time.Now()) {
+		return &LimitCheckResult{Allowed: true}, nil
+	}
+	return CanAddUser(db, firmID)
+}
+
+// DeactivateUserSeat records a user's deactivation and schedules the seat release
+// for the end of the current billing period. Call after the user is saved inactive.
+func DeactivateUserSeat(db *gorm.DB, firmID string, user *models.User) error {
+	now := time.Now()
+	updates := map[string]interface{}{"deactivated_at": now}
+
+	if !user.IsBillable() {
+		user.DeactivatedAt = &now
+		return db.Model(user).UpdateColumns(updates).Error
+	}
+
+	subscription, err := GetFirmSubscription(db, firmID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	releaseAt := SeatReleaseDate(subscription, now)
+	if releaseAt.After(now) {
+		// Seat stays billed (and counted) until the period ends
+		updates["seat_held_until"] = releaseAt
+		if err := db.Model(user).UpdateColumns(updates).Error; err != nil {
+			return err
+		}
+		user.DeactivatedAt = &now
+		user.SeatHeldUntil = &releaseAt
+		return nil
+	}
+
+	updates["seat_held_until"] = nil
+	if err := db.Model(user).UpdateColumns(updates).Error; err != nil {
+		return err
+	}
+	user.DeactivatedAt = &now
+	user.SeatHeldUntil = nil
+
+	if err := UpdateFirmUsageAfterUserChange(db, firmID, -1); err != nil {
+		return err
+	}
+	SeatChangeHook(db, SeatChangeEvent{
+		FirmID:          firmID,
+		UserID:          user.ID,
+		Action:          SeatActionReleased,
+		EffectiveAt:     now,
+		ProrationFactor: ProrationFactor(subscription, now),
+	})
+	return nil
+}
+
+// ReactivateUserSeat records a user's reactivation. If the seat was already
+// released a new one is claimed. Call after CanReactivateUser allowed it and
+// the user was saved active.
+func ReactivateUserSeat(db *gorm.DB, firmID string, user *models.User) error {
+	now := time.Now()
+	heldSeat := user.SeatHeldUntil != nil && user.SeatHeldUntil.After(now)
+
+	if err := db.Model(user).UpdateColumns(map[string]interface{}{
+		"deactivated_at":  nil,
+		"seat_held_until": nil,
+	}).Error; err != nil {
+		return err
+	}
+	user.DeactivatedAt = nil
+	user.SeatHeldUntil = nil
+
+	if !user.IsBillable() || heldSeat {
+		return nil
+	}
+
+	if err := UpdateFirmUsageAfterUserChange(db, firmID, 1); err != nil {
+		return err
+	}
+
+	subscription, _ := GetFirmSubscription(db, firmID)
+	SeatChangeHook(db, SeatChangeEvent{
+		FirmID:          firmID,
+		UserID:          user.ID,
+		Action:          SeatActionClaimed,
+		EffectiveAt:     now,
+		ProrationFactor: ProrationFactor(subscription, now),
+	})
+	return nil
+}
+
+// ReleaseExpiredSeats frees seats held by deactivated users whose billing period ended
+// This should be run as a scheduled job
+func ReleaseExpiredSeats(db *gorm.DB) error {
+	now := time.Now()
+
+	var users []models.User
+	if err := db.Where("is_active = ? AND seat_held_until IS NOT NULL AND seat_held_until <= ?", false, now).
+		Find(&users).Error; err != nil {
+		return err
+	}
+
+	for i := range users {
+		user := &users[i]
+		if err := db.Model(user).UpdateColumn("seat_held_until", nil).Error; err != nil {
+			log.Printf("Error releasing seat for user %s: %v", user.ID, err)
+			continue
+		}
+		if user.FirmID == nil || !user.IsBillable() {
+			continue
+		}
+		if err := UpdateFirmUsageAfterUserChange(db, *user.FirmID, -1); err != nil {
+			log.Printf("Error updating usage after seat release for firm %s: %v", *user.FirmID, err)
+		}
+		SeatChangeHook(db, SeatChangeEvent{
+			FirmID:      *user.FirmID,
+			UserID:      user.ID,
+			Action:      SeatActionReleased,
+			EffectiveAt: now,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSeatReleaseDate(t *testing.T) {
+	now := time.Now()
+	periodStart := now.AddDate(0, 0, -10)
+	periodEnd := now.AddDate(0, 0, 20)
+
+	t.Run("Trial releases immediately", func(t *testing.T) {
+		sub := &models.FirmSubscription{Status: models.SubscriptionStatusTrialing}
+		assert.Equal(t, now, SeatReleaseDate(sub, now))
+	})
+
+	t.Run("Paid holds until period end", func(t *testing.T) {
+		sub := &models.FirmSubscription{Status: models.SubscriptionStatusActive, CurrentPeriodStart: &periodStart, CurrentPeriodEnd: &periodEnd}
+		assert.Equal(t, periodEnd, SeatReleaseDate(sub, now))
+	})
+
+	t.Run("No subscription releases immediately", func(t *testing.T) {
+		assert.Equal(t, now, SeatReleaseDate(nil, now))
+	})
+}
+
+func TestProrationFactor(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 30)
+	sub := &models.FirmSubscription{CurrentPeriodStart: &start, CurrentPeriodEnd: &end}
+
+	assert.Equal(t, 1.0, ProrationFactor(sub, start))
+	assert.InDelta(t, 0.5, ProrationFactor(sub, start.AddDate(0, 0, 15)), 0.0001)
+	assert.Equal(t, 0.0, ProrationFactor(sub, end.Add(time.Hour)))
+	assert.Equal(t, 0.0, ProrationFactor(&models.FirmSubscription{}, start))
+}
+
+func TestSeatLifecycle(t *testing.T) {
+	db := setupSubscriptionTestDB()
+	SeedDefaultPlans(db)
+
+	firmID := "f1"
+	db.Create(&models.Firm{ID: firmID, Name: "Test Firm"})
+
+	var starterPlan models.Plan
+	db.Where("tier = ?", models.PlanTierStarter).First(&starterPlan)
+	periodStart := time.Now().AddDate(0, 0, -5)
+	periodEnd := time.Now().AddDate(0, 0, 25)
+	db.Create(&models.FirmSubscription{
+		FirmID: firmID, PlanID: starterPlan.ID, Status: models.SubscriptionStatusActive,
+		CurrentPeriodStart: &periodStart, CurrentPeriodEnd: &periodEnd,
+	})
+
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		db.Create(&models.User{ID: id, FirmID: &firmID, Role: "lawyer", IsActive: true, Email: id + "@test.com"})
+	}
+	_, err := RecalculateFirmUsage(db, firmID)
+	assert.NoError(t, err)
+
+	var events []SeatChangeEvent
+	SeatChangeHook = func(_ *gorm.DB, event SeatChangeEvent) { events = append(events, event) }
+	defer func() { SeatChangeHook = func(*gorm.DB, SeatChangeEvent) {} }()
+
+	var user models.User
+	db.First(&user, "id = ?", "u1")
+
+	t.Run("Deactivation holds seat until period end", func(t *testing.T) {
+		db.Model(&user).Update("is_active", false)
+		user.IsActive = false
+		assert.NoError(t, DeactivateUserSeat(db, firmID, &user))
+
+		assert.NotNil(t, user.SeatHeldUntil)
+		assert.True(t, user.SeatHeldUntil.Equal(periodEnd))
+		assert.Empty(t, events)
+
+		summary, err := GetSeatSummary(db, firmID, &starterPlan)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, summary.Active)
+		assert.Equal(t, 1, summary.PendingRelease)
+		assert.Equal(t, 5, summary.InUse)
+		assert.Equal(t, 5, summary.Paid)
+
+		usage, _ := RecalculateFirmUsage(db, firmID)
+		assert.Equal(t, 5, usage.CurrentUsers)
+
+		// Firm is full, but the held seat still belongs to the user
+		result, err := CanReactivateUser(db, firmID, &user)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("Expired hold releases seat", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		db.Model(&user).Update("seat_held_until", past)
+		assert.NoError(t, ReleaseExpiredSeats(db))
+
+		user = models.User{}
+		db.First(&user, "id = ?", "u1")
+		assert.Nil(t, user.SeatHeldUntil)
+		assert.Len(t, events, 1)
+		assert.Equal(t, SeatActionReleased, events[0].Action)
+
+		usage, _ := GetOrCalculateFirmUsage(db, firmID)
+		assert.Equal(t, 4, usage.CurrentUsers)
+	})
+
+	t.Run("Reactivation re-checks seat limit", func(t *testing.T) {
+		db.Create(&models.User{ID: "u6", FirmID: &firmID, Role: "staff", IsActive: true, Email: "u6@test.com"})
+		UpdateFirmUsageAfterUserChange(db, firmID, 1)
+
+		result, err := CanReactivateUser(db, firmID, &user)
+		assert.ErrorIs(t, err, ErrUserLimitReached)
+		assert.False(t, result.Allowed)
+
+		db.Delete(&models.User{}, "id = ?", "u6")
+		UpdateFirmUsageAfterUserChange(db, firmID, -1)
+
+		result, err = CanReactivateUser(db, firmID, &user)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		db.Model(&user).Update("is_active", true)
+		user.IsActive = true
+		assert.NoError(t, ReactivateUserSeat(db, firmID, &user))
+		assert.Len(t, events, 2)
+		assert.Equal(t, SeatActionClaimed, events[1].Action)
+		assert.Greater(t, events[1].ProrationFactor, 0.0)
+
+		usage, _ := GetOrCalculateFirmUsage(db, firmID)
+		assert.Equal(t, 5, usage.CurrentUsers)
+	})
+}
@@ -2,21 +2,26 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"law_flow_app_go/config"
+	"law_flow_app_go/models"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
 )
 
@@ -26,6 +31,8 @@ type StorageProvider interface {
 	UploadReader(ctx context.Context, reader io.Reader, key string, contentType string, size int64) (*StorageResult, error)
 	Delete(ctx context.Context, key string) error
 	Get(ctx context.Context, key string) (io.ReadCloser, string, error) // Returns reader, content-type, error
+	Exists(ctx context.Context, key string) (bool, error)               // Metadata-only check; false when the object is gone
+	GetRange(ctx context.Context, key string, byteRange string) (*StorageObject, error)
 	GetSignedURL(ctx context.Context, key string, expiration time.Duration) (string, error)
 	GetPublicURL(key string) string
 	IsConfigured() bool
@@ -41,9 +48,36 @@ type StorageResult struct {
 	URL              string // Public or signed URL
 }
 
+// StorageObject is an object opened for streaming, possibly limited to a byte range
+type StorageObject struct {
+	Body          io.ReadCloser // An io.ReadSeeker for local files, so callers can serve ranges themselves
+	ContentType   string
+	ContentLength int64  // Bytes in Body
+	ContentRange  string // Content-Range of a partial response; empty when Body holds the whole object
+	ETag          string
+	LastModified  time.Time
+}
+
+// ErrInvalidRange is returned when a requested byte range cannot be satisfied
+var ErrInvalidRange = errors.New("requested range not satisfiable")
+
 // Storage is the global storage instance
 var Storage StorageProvider
 
+// SignedURLExpiration is the lifetime of signed download URLs. Pages only link the app's
+// download endpoint, which signs a new URL on every click, so an old tab never holds an
+// expired link.
+const SignedURLExpiration = 15 * time.Minute
+
+// UsesSignedURLs reports whether a firm's documents are delivered by redirecting to signed
+// R2 URLs rather than streamed through the app. Local storage is always streamed
+func UsesSignedURLs(firm *models.Firm) bool {
+	if _, ok := Storage.(*R2Storage); !ok {
+		return false
+	}
+	return firm == nil || !firm.DocumentProxyEnabled
+}
+
 // InitializeStorage sets up the storage provider based on configuration
 func InitializeStorage(cfg *config.Config) {
 	if cfg.R2AccountID != "" && cfg.R2AccessKeyID != "" && cfg.R2SecretAccessKey != "" && cfg.R2BucketName != "" {
@@ -239,6 +273,67 @@ func (r *R2Storage) Get(ctx context.Context, key string) (io.ReadCloser, string,
 	return result.Body, contentType, nil
 }
 
+// Exists checks whether an object is present in R2 with a HEAD request
+func (r *R2Storage) Exists(ctx context.Context, key string) (bool, error) {
+	if err := ValidatePath(key); err != nil {
+		return false, err
+	}
+	_, err := r.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to head object in R2: %w", err)
+}
+
+// GetRange retrieves an object from R2, forwarding an HTTP Range header value (e.g. "bytes=0-1023")
+// so only the requested bytes leave the bucket. An empty byteRange reads the whole object
+func (r *R2Storage) GetRange(ctx context.Context, key string, byteRange string) (*StorageObject, error) {
+	if err := ValidatePath(key); err != nil {
+		return nil, err
+	}
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(key),
+	}
+	if byteRange != "" {
+		input.Range = aws.String(byteRange)
+	}
+
+	result, err := r.client.GetObject(ctx, input)
+	if err != nil {
+		var respErr *awshttp.ResponseError
+		if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusRequestedRangeNotSatisfiable {
+			return nil, ErrInvalidRange
+		}
+		return nil, fmt.Errorf("failed to get object from R2: %w", err)
+	}
+
+	obj := &StorageObject{
+		Body:          result.Body,
+		ContentType:   aws.ToString(result.ContentType),
+		ContentLength: aws.ToInt64(result.ContentLength),
+		ContentRange:  aws.ToString(result.ContentRange),
+		ETag:          aws.ToString(result.ETag),
+		LastModified:  aws.ToTime(result.LastModified),
+	}
+	if obj.ContentType == "" {
+		obj.ContentType = "application/octet-stream"
+	}
+	return obj, nil
+}
+
 // GetSignedURL generates a presigned URL for temporary access
 func (r *R2Storage) GetSignedURL(ctx context.Context, key string, expiration time.Duration) (string, error) {
 	if err := ValidatePath(key); err != nil {
@@ -359,23 +454,62 @@ func (l *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, stri
 		return nil, "", fmt.Errorf("failed to open file: %w", err)
 	}
 
-	// Detect content type from extension
-	contentType := "application/octet-stream"
-	ext := strings.ToLower(filepath.Ext(key))
-	switch ext {
+	return file, localContentType(key), nil
+}
+
+// GetRange opens a local file for streaming. The whole file is returned as a seekable body,
+// so the byte range is left to the caller (e.g. http.ServeContent)
+func (l *LocalStorage) GetRange(ctx context.Context, key string, byteRange string) (*StorageObject, error) {
+	if err := ValidatePath(key); err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filepath.Join(l.baseDir, key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return &StorageObject{
+		Body:          file,
+		ContentType:   localContentType(key),
+		ContentLength: info.Size(),
+		LastModified:  info.ModTime(),
+	}, nil
+}
+
+// localContentType detects a local file's content type from its extension
+func localContentType(key string) string {
+	switch strings.ToLower(filepath.Ext(key)) {
 	case ".pdf":
-		contentType = "application/pdf"
+		return "application/pdf"
 	case ".png":
-		contentType = "image/png"
+		return "image/png"
 	case ".jpg", ".jpeg":
-		contentType = "image/jpeg"
+		return "image/jpeg"
 	case ".doc":
-		contentType = "application/msword"
+		return "application/msword"
 	case ".docx":
-		contentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
 	}
+	return "application/octet-stream"
+}
 
-	return file, contentType, nil
+// Exists checks whether a file is present on the local filesystem
+func (l *LocalStorage) Exists(ctx context.Context, key string) (bool, error) {
+	if err := ValidatePath(key); err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(filepath.Join(l.baseDir, key)); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat file: %w", err)
+	}
+	return true, nil
 }
 
 // GetSignedURL for local storage just returns the file path (no signing needed)
@@ -63,6 +63,17 @@ func TestLocalStorage(t *testing.T) {
 		assert.Equal(t, "image/jpeg", retrievedType)
 	})
 
+	t.Run("GetRange returns a seekable file", func(t *testing.T) {
+		obj, err := storage.GetRange(ctx, key, "bytes=0-4")
+		assert.NoError(t, err)
+		defer obj.Body.Close()
+
+		_, seekable := obj.Body.(io.ReadSeeker)
+		assert.True(t, seekable, "local files are served with range support by the caller")
+		assert.Equal(t, size, obj.ContentLength)
+		assert.False(t, obj.LastModified.IsZero())
+	})
+
 	t.Run("Delete removes file", func(t *testing.T) {
 		err := storage.Delete(ctx, key)
 		assert.NoError(t, err)
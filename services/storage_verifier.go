@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	storageVerifyBatchSize = 100
+	storageVerifyTimeout   = 10 * time.Second
+	missingFilesListLimit  = 5 // File names listed in the admin notification
+)
+
+// MissingFile is a document whose file was found missing from storage
+type MissingFile struct {
+	FirmID       string
+	DocumentType string // CaseDocument or ServiceDocument
+	DocumentID   string
+	FileName     string
+	LinkURL      string
+}
+
+// StorageVerificationResult summarizes a storage verification run
+type StorageVerificationResult struct {
+	Checked      int
+	Missing      int // Documents currently flagged, including ones flagged on earlier runs
+	Restored     int // Previously flagged documents whose file is back
+	Failed       int // Checks that could not complete (storage unreachable); left unchanged
+	NewlyMissing []MissingFile
+}
+
+// storageCheck is the outcome of a single object check
+type storageCheck int
+
+const (
+	storageCheckPresent storageCheck = iota
+	storageCheckMissing
+	storageCheckFailed
+)
+
+// checkStorageObject HEADs a single object, bounding the call with a timeout
+func checkStorageObject(provider StorageProvider, key string) storageCheck {
+	ctx, cancel := context.WithTimeout(context.Background(), storageVerifyTimeout)
+	defer cancel()
+
+	exists, err := provider.Exists(ctx, key)
+	if err != nil {
+		log.Printf("[STORAGE] Could not verify %s: %v", key, err)
+		return storageCheckFailed
+	}
+	if !exists {
+		return storageCheckMissing
+	}
+	return storageCheckPresent
+}
+
+// applyStorageCheck records the outcome of a check on a document row. A document is only
+// flagged once, so admins are notified the first time its file goes missing.
+func applyStorageCheck(db *gorm.DB, model interface{}, missingAt *time.Time, check storageCheck, now time.Time, result *StorageVerificationResult) bool {
+	result.Checked++
+	updates := map[string]interface{}{"file_checked_at": now}
+	newlyMissing := false
+
+	switch check {
+	case storageCheckFailed:
+		result.Failed++
+		return false
+	case storageCheckMissing:
+		result.Missing++
+		if missingAt == nil {
+			updates["file_missing_at"] = now
+			newlyMissing = true
+		}
+	case storageCheckPresent:
+		if missingAt != nil {
+			updates["file_missing_at"] = nil
+			result.Restored++
+		}
+	}
+
+	if err := db.Model(model).UpdateColumns(updates).Error; err != nil {
+		log.Printf("[STORAGE] Error recording storage check: %v", err)
+		return false
+	}
+	return newlyMissing
+}
+
+// FileStillMissing re-checks a document flagged missing when someone asks for its file, so a
+// restored file is served right away instead of after the next nightly run. The flag is
+// cleared when the file is back; a check that cannot complete counts as still missing.
+func FileStillMissing(db *gorm.DB, provider StorageProvider, model interface{}, key string) bool {
+	if provider == nil || checkStorageObject(provider, key) != storageCheckPresent {
+		return true
+	}
+	if err := db.Model(model).UpdateColumns(map[string]interface{}{
+		"file_missing_at": nil,
+		"file_checked_at": time.Now(),
+	}).Error; err != nil {
+		log.Printf("[STORAGE] Error clearing missing flag of %s: %v", key, err)
+	}
+	return false
+}
+
+// VerifyDocumentStorage checks in batches that the file of every case and service document
+// still exists in storage, flags the documents whose file is gone and clears the flag of
+// those whose file is back. Admins of each affected firm are notified of newly missing files.
+// This should be run as a scheduled job
+func VerifyDocumentStorage(db *gorm.DB, provider StorageProvider, now time.Time) (*StorageVerificationResult, error) {
+	result := &StorageVerificationResult{}
+	if provider == nil || !provider.IsConfigured() {
+		return result, nil
+	}
+
+	var caseDocs []models.CaseDocument
+	err := db.Select("id", "firm_id", "case_id", "file_path", "file_original_name", "file_missing_at").
+		Where("file_path <> ''").
+		FindInBatches(&caseDocs, storageVerifyBatchSize, func(tx *gorm.DB, batch int) error {
+			for i := range caseDocs {
+				doc := &caseDocs[i]
+				check := checkStorageObject(provider, doc.FilePath)
+				if applyStorageCheck(db, &models.CaseDocument{ID: doc.ID}, doc.FileMissingAt, check, now, result) {
+					missing := MissingFile{FirmID: doc.FirmID, DocumentType: "CaseDocument", DocumentID: doc.ID, FileName: doc.FileOriginalName}
+					if doc.CaseID != nil {
+						missing.LinkURL = "/cases/" + *doc.CaseID
+					}
+					result.NewlyMissing = append(result.NewlyMissing, missing)
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return result, err
+	}
+
+	var serviceDocs []models.ServiceDocument
+	err = db.Select("id", "firm_id", "service_id", "file_path", "file_original_name", "file_missing_at").
+		Where("file_path <> ''").
+		FindInBatches(&serviceDocs, storageVerifyBatchSize, func(tx *gorm.DB, batch int) error {
+			for i := range serviceDocs {
+				doc := &serviceDocs[i]
+				check := checkStorageObject(provider, doc.FilePath)
+				if applyStorageCheck(db, &models.ServiceDocument{ID: doc.ID}, doc.FileMissingAt, check, now, result) {
+					result.NewlyMissing = append(result.NewlyMissing, MissingFile{
+						FirmID: doc.FirmID, DocumentType: "ServiceDocument", DocumentID: doc.ID,
+						FileName: doc.FileOriginalName, LinkURL: "/services/" + doc.ServiceID,
+					})
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return result, err
+	}
+
+	notifyMissingFiles(db, result.NewlyMissing)
+	log.Printf("[STORAGE] Verified %d documents: %d missing (%d new), %d restored, %d unchecked",
+		result.Checked, result.Missing, len(result.NewlyMissing), result.Restored, result.Failed)
+	return result, nil
+}
+
+// notifyMissingFiles sends every active admin of a firm one notification listing the
+// firm's newly missing files
+func notifyMissingFiles(db *gorm.DB, missing []MissingFile) {
+	byFirm := make(map[string][]MissingFile)
+	var firmIDs []string
+	for _, file := range missing {
+		if _, ok := byFirm[file.FirmID]; !ok {
+			firmIDs = append(firmIDs, file.FirmID)
+		}
+		byFirm[file.FirmID] = append(byFirm[file.FirmID], file)
+	}
+
+	notificationService := NewNotificationService(db)
+	for _, firmID := range firmIDs {
+		files := byFirm[firmID]
+		names := make([]string, 0, missingFilesListLimit)
+		for i, file := range files {
+			if i == missingFilesListLimit {
+				break
+			}
+			names = append(names, file.FileName)
+		}
+		// Link straight to the document's page when there is a single one to look at
+		link := ""
+		if len(files) == 1 {
+			link = files[0].LinkURL
+		}
+
+		var admins []models.User
+		if err := db.Where("firm_id = ? AND role = ? AND is_active = ?", firmID, "admin", true).Find(&admins).Error; err != nil {
+			log.Printf("[STORAGE] Error fetching admins of firm %s: %v", firmID, err)
+			continue
+		}
+		for _, admin := range admins {
+			lang := admin.Language
+			if lang == "" {
+				lang = "es"
+			}
+			args := map[string]interface{}{"count": len(files), "files": strings.Join(names, ", ")}
+			adminID := admin.ID
+			notification := &models.Notification{
+				FirmID:  firmID,
+				UserID:  &adminID,
+				Type:    models.NotificationTypeStorage,
+				Title:   i18n.Translate(lang, "storage.missing_files.title", args),
+				Message: i18n.Translate(lang, "storage.missing_files.message", args),
+				LinkURL: link,
+			}
+			if err := notificationService.CreateNotification(notification); err != nil {
+				log.Printf("[STORAGE] Error notifying admin %s of missing files: %v", admin.ID, err)
+			}
+		}
+	}
+}
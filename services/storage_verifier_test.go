@@ -0,0 +1,110 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"law_flow_app_go/models"
+	"law_flow_app_go/services/i18n"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupStorageVerifierTestDB(t *testing.T) (*gorm.DB, *models.Firm, *models.User) {
+	i18n.Load()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Firm{}, &models.User{}, &models.CaseDocument{},
+		&models.ServiceDocument{}, &models.Notification{}))
+
+	firm := &models.Firm{Name: "Storage Firm", BillingEmail: "billing@storage.com"}
+	require.NoError(t, db.Create(firm).Error)
+	admin := &models.User{FirmID: &firm.ID, Name: "Admin", Email: "admin@storage.com", Role: "admin", IsActive: true, Language: "en"}
+	require.NoError(t, db.Create(admin).Error)
+	require.NoError(t, db.Create(&models.User{FirmID: &firm.ID, Name: "Lawyer", Email: "lawyer@storage.com", Role: "lawyer", IsActive: true}).Error)
+	return db, firm, admin
+}
+
+func TestVerifyDocumentStorage(t *testing.T) {
+	db, firm, admin := setupStorageVerifierTestDB(t)
+	storage := NewLocalStorage(t.TempDir())
+	ctx := context.Background()
+	caseID := "case-storage"
+
+	_, err := storage.UploadReader(ctx, bytes.NewReader([]byte("pdf")), "firms/f/present.pdf", "application/pdf", 3)
+	require.NoError(t, err)
+	present := &models.CaseDocument{FirmID: firm.ID, CaseID: &caseID, FileName: "present.pdf", FileOriginalName: "present.pdf", FilePath: "firms/f/present.pdf", FileSize: 3}
+	gone := &models.CaseDocument{FirmID: firm.ID, CaseID: &caseID, FileName: "gone.pdf", FileOriginalName: "gone.pdf", FilePath: "firms/f/gone.pdf", FileSize: 3}
+	goneService := &models.ServiceDocument{FirmID: firm.ID, ServiceID: "service-storage", FileName: "memo.docx", FileOriginalName: "memo.docx", FilePath: "firms/f/memo.docx", FileSize: 3}
+	for _, doc := range []interface{}{present, gone, goneService} {
+		require.NoError(t, db.Create(doc).Error)
+	}
+
+	now := time.Now()
+	result, err := VerifyDocumentStorage(db, storage, now)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Checked)
+	assert.Equal(t, 2, result.Missing)
+	assert.Len(t, result.NewlyMissing, 2)
+
+	require.NoError(t, db.First(present, "id = ?", present.ID).Error)
+	assert.False(t, present.IsFileMissing())
+	assert.NotNil(t, present.FileCheckedAt)
+	require.NoError(t, db.First(gone, "id = ?", gone.ID).Error)
+	assert.True(t, gone.IsFileMissing())
+	require.NoError(t, db.First(goneService, "id = ?", goneService.ID).Error)
+	assert.True(t, goneService.IsFileMissing())
+
+	// Only admins get one summary notification per firm
+	var notifications []models.Notification
+	require.NoError(t, db.Where("type = ?", models.NotificationTypeStorage).Find(&notifications).Error)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, admin.ID, *notifications[0].UserID)
+	assert.Contains(t, notifications[0].Title, "2")
+	assert.Contains(t, notifications[0].Message, "gone.pdf")
+	assert.Contains(t, notifications[0].Message, "memo.docx")
+
+	// Files already flagged are not reported again; restored files are unflagged
+	_, err = storage.UploadReader(ctx, bytes.NewReader([]byte("pdf")), "firms/f/gone.pdf", "application/pdf", 3)
+	require.NoError(t, err)
+	result, err = VerifyDocumentStorage(db, storage, now.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Missing)
+	assert.Equal(t, 1, result.Restored)
+	assert.Empty(t, result.NewlyMissing)
+
+	var restored models.CaseDocument
+	require.NoError(t, db.First(&restored, "id = ?", gone.ID).Error)
+	assert.False(t, restored.IsFileMissing())
+	var count int64
+	db.Model(&models.Notification{}).Where("type = ?", models.NotificationTypeStorage).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestVerifyDocumentStorageUnreachable(t *testing.T) {
+	db, firm, _ := setupStorageVerifierTestDB(t)
+	caseID := "case-storage"
+	doc := &models.CaseDocument{FirmID: firm.ID, CaseID: &caseID, FileName: "a.pdf", FileOriginalName: "a.pdf", FilePath: "a.pdf", FileSize: 1}
+	require.NoError(t, db.Create(doc).Error)
+
+	// A storage outage must not flag documents as missing
+	mStorage := new(MockStorageProvider)
+	mStorage.On("IsConfigured").Return(true)
+	mStorage.On("Exists", mock.Anything, "a.pdf").Return(false, errors.New("connection reset"))
+
+	result, err := VerifyDocumentStorage(db, mStorage, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 0, result.Missing)
+
+	require.NoError(t, db.First(doc, "id = ?", doc.ID).Error)
+	assert.False(t, doc.IsFileMissing())
+	assert.Nil(t, doc.FileCheckedAt)
+	mStorage.AssertExpectations(t)
+}
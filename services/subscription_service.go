@@ -46,6 +46,7 @@ type SubscriptionInfo struct {
 	ShowTrialWarning bool
 	HasTemplates     bool
 	ActiveAddOns     []models.FirmAddOn
+	Seats            *SeatSummary
 }
 
 // GetFirmSubscription retrieves the subscription for a firm
@@ -76,6 +77,7 @@ func GetFirmSubscriptionInfo(db *gorm.DB, firmID string) (*SubscriptionInfo, err
 	effectiveCases := GetEffectiveCaseLimit(db, firmID, &subscription.Plan)
 	hasTemplates := HasTemplatesAccess(db, firmID, &subscription.Plan)
 	activeAddOns, _ := GetFirmAddOns(db, firmID)
+	seats, _ := GetSeatSummary(db, firmID, &subscription.Plan)
 
 	info := &SubscriptionInfo{
 		Subscription:     subscription,
@@ -88,6 +90,7 @@ func GetFirmSubscriptionInfo(db *gorm.DB, firmID string) (*SubscriptionInfo, err
 		ShowTrialWarning: subscription.ShouldShowTrialWarning(),
 		HasTemplates:     hasTemplates,
 		ActiveAddOns:     activeAddOns,
+		Seats:            seats,
 	}
 
 	// Calculate percentages
@@ -518,10 +521,11 @@ func GetOrCalculateFirmUsage(db *gorm.DB, firmID string) (*models.FirmUsage, err
 // RecalculateFirmUsage calculates fresh usage from source tables
 func RecalculateFirmUsage(db *gorm.DB, firmID string) (*models.FirmUsage, error) {
 	// Count users (excluding clients and superadmins - only count billable users)
+	// Deactivated users still count while their seat is held until period end
 	var userCount int64
 	db.Model(&models.User{}).
-		Where("firm_id = ? AND role IN (?, ?, ?) AND is_active = ?",
-			firmID, "admin", "lawyer", "staff", true).
+		Where("firm_id = ? AND role IN (?, ?, ?) AND (is_active = ? OR seat_held_until > ?)",
+			firmID, "admin", "lawyer", "staff", true, time.Now()).
 		Count(&userCount)
 
 	// Sum storage from documents
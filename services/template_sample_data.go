@@ -0,0 +1,115 @@
+package services
+
+import (
+	"law_flow_app_go/models"
+	"time"
+)
+
+// sampleTemplateProfiles holds synthetic preview data per country code.
+// The empty key is the fallback for countries without a dedicated profile.
+var sampleTemplateProfiles = map[string]TemplateData{
+	"COL": {
+		Client: ClientData{
+			Name:           "María Fernanda Gómez Restrepo",
+			Email:          "maria.gomez@ejemplo.com",
+			Phone:          "+57 310 555 1234",
+			DocumentType:   "Cédula de Ciudadanía",
+			DocumentNumber: "1.020.345.678",
+			Address:        "Carrera 7 # 71-21, Bogotá D.C.",
+		},
+		Case: CaseData{
+			Number:      "11001310300120260012300",
+			Title:       "Gómez Restrepo vs. Inversiones Andinas S.A.S.",
+			Description: "Proceso verbal de responsabilidad civil contractual por incumplimiento de contrato de suministro.",
+			Status:      "Open",
+			Domain:      "Derecho Privado",
+			Branch:      "Civil",
+			Subtypes:    "Responsabilidad Contractual",
+		},
+		Firm: FirmData{
+			Name:         "Abogados Asociados S.A.S.",
+			Address:      "Calle 93 # 11-26, Oficina 502",
+			City:         "Bogotá",
+			Phone:        "+57 601 555 0000",
+			BillingEmail: "facturacion@ejemplo.com",
+			InfoEmail:    "contacto@ejemplo.com",
+		},
+		Lawyer: LawyerData{
+			Name:  "Juan Carlos Pérez Londoño",
+			Email: "jcperez@ejemplo.com",
+			Phone: "+57 315 555 9876",
+		},
+	},
+	"": {
+		Client: ClientData{
+			Name:           "John Doe",
+			Email:          "john.doe@example.com",
+			Phone:          "+1 555-123-4567",
+			DocumentType:   "Passport",
+			DocumentNumber: "X12345678",
+			Address:        "123 Main St, Springfield",
+		},
+		Case: CaseData{
+			Number:      "2026-001",
+			Title:       "Doe vs. Acme Corporation",
+			Description: "Breach of contract claim arising from an unpaid supply agreement.",
+			Status:      "Open",
+			Domain:      "Private Law",
+			Branch:      "Civil",
+			Subtypes:    "Contract Dispute",
+		},
+		Firm: FirmData{
+			Name:         "Smith & Associates",
+			Address:      "456 Law St, Suite 200",
+			City:         "New York",
+			Phone:        "+1 555-987-6543",
+			BillingEmail: "billing@example.com",
+			InfoEmail:    "info@example.com",
+		},
+		Lawyer: LawyerData{
+			Name:  "Jane Smith, Esq.",
+			Email: "jane.smith@example.com",
+			Phone: "+1 555-111-2222",
+		},
+	},
+}
+
+// BuildSampleTemplateData returns synthetic template data for previews, localized to the
+// firm's country. Firm fields that are filled in on the firm profile take precedence.
+func BuildSampleTemplateData(firm *models.Firm, now time.Time) TemplateData {
+	countryCode := ""
+	if firm != nil && firm.Country != nil {
+		countryCode = firm.Country.Code
+	}
+	data, ok := sampleTemplateProfiles[countryCode]
+	if !ok {
+		data = sampleTemplateProfiles[""]
+	}
+
+	openedAt := now.AddDate(0, -2, 0)
+	data.Case.OpenedAt = openedAt.Format("January 2, 2006")
+	data.Today = DateData{
+		Date:     now.Format("2006-01-02"),
+		DateLong: now.Format("January 2, 2006"),
+		Year:     now.Format("2006"),
+	}
+
+	if firm != nil {
+		data.Firm.Name = valueOrDefault(firm.Name, data.Firm.Name)
+		data.Firm.Address = valueOrDefault(firm.Address, data.Firm.Address)
+		data.Firm.City = valueOrDefault(firm.City, data.Firm.City)
+		data.Firm.Phone = valueOrDefault(firm.Phone, data.Firm.Phone)
+		data.Firm.BillingEmail = valueOrDefault(firm.BillingEmail, data.Firm.BillingEmail)
+		data.Firm.InfoEmail = valueOrDefault(firm.InfoEmail, data.Firm.InfoEmail)
+	}
+
+	return data
+}
+
+// valueOrDefault returns value unless it is empty
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
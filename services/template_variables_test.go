@@ -193,3 +193,27 @@ func TestSafeHelpers(t *testing.T) {
 	assert.Equal(t, "", safeStringPtr(nil))
 	assert.Equal(t, "test", safeStringPtr(&s))
 }
+
+func TestBuildSampleTemplateData(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("uses the firm country profile", func(t *testing.T) {
+		firm := &models.Firm{Name: "Lex & Co", Country: &models.Country{Code: "COL"}}
+		data := BuildSampleTemplateData(firm, now)
+
+		assert.Equal(t, "Cédula de Ciudadanía", data.Client.DocumentType)
+		assert.Equal(t, "Lex & Co", data.Firm.Name)
+		assert.Equal(t, "Bogotá", data.Firm.City)
+		assert.Equal(t, "2026-03-10", data.Today.Date)
+		assert.Equal(t, "January 10, 2026", data.Case.OpenedAt)
+	})
+
+	t.Run("falls back to generic data", func(t *testing.T) {
+		data := BuildSampleTemplateData(&models.Firm{Country: &models.Country{Code: "XYZ"}}, now)
+		assert.Equal(t, "John Doe", data.Client.Name)
+		assert.Equal(t, "Smith & Associates", data.Firm.Name)
+
+		rendered := RenderTemplate("<p>{{client.name}} - {{case.number}}</p>", BuildSampleTemplateData(nil, now))
+		assert.Equal(t, "<p>John Doe - 2026-001</p>", rendered)
+	})
+}
@@ -717,6 +717,51 @@ document.addEventListener('alpine:init', () => {
     }));
 });
 
+// Public Registry Lookup Alpine Helper
+// Looks up the tax ID typed in the form and fills the fields that are still empty.
+// `fields` maps registry record keys to the form's input names, e.g. { tax_id: 'document_number', name: 'name' }.
+document.addEventListener('alpine:init', () => {
+    Alpine.data('registryLookup', (fields, unavailableMessage) => ({
+        loading: false,
+        message: '',
+        isError: false,
+
+        lookup() {
+            const form = this.$el.closest('form');
+            const taxIdInput = form?.elements[fields.tax_id];
+            if (!taxIdInput) return;
+
+            this.loading = true;
+            this.message = '';
+            fetch('/api/registry/lookup?tax_id=' + encodeURIComponent(taxIdInput.value))
+                .then(res => res.json().then(data => ({ ok: res.ok, data })))
+                .then(({ ok, data }) => {
+                    this.isError = !ok || data.stale;
+                    this.message = data.message || '';
+                    if (!ok) return;
+
+                    Object.entries(fields).forEach(([key, inputName]) => {
+                        const input = form.elements[inputName];
+                        const value = data.record[key];
+                        if (input && value && !input.value) {
+                            input.value = value;
+                            // Keep x-model bindings in sync
+                            input.dispatchEvent(new Event('input', { bubbles: true }));
+                        }
+                    });
+                })
+                .catch(err => {
+                    console.error('Error looking up registry:', err);
+                    this.isError = true;
+                    this.message = unavailableMessage;
+                })
+                .finally(() => {
+                    this.loading = false;
+                });
+        }
+    }));
+});
+
 
 
 
@@ -19,6 +19,7 @@ function templateEditor(configuredPageHeight = 1056) {
         variables: [],
         openDropdown: '', // Track which dropdown is open (heading, fontSize, color)
         showColorAdvanced: false, // Toggle for advanced color picker
+        showPreview: false, // Side-by-side preview with sample data
 
         init() {
             // Load variables
@@ -60,9 +61,24 @@ function templateEditor(configuredPageHeight = 1056) {
             clearTimeout(this.debounceTimer);
             this.debounceTimer = setTimeout(() => {
                 this.checkAutoPageBreaks();
+                if (this.showPreview) this.refreshPreview();
             }, 1000);
         },
 
+        togglePreview() {
+            this.showPreview = !this.showPreview;
+            if (this.showPreview) this.refreshPreview();
+        },
+
+        // Render the current (unsaved) content against the selected sample data
+        refreshPreview() {
+            const input = document.getElementById('preview-content-input');
+            if (!input) return; // Panel not loaded yet; it refreshes itself on load
+
+            input.value = getCleanTemplateContent();
+            htmx.trigger('#template-preview-form', 'submit');
+        },
+
         async loadVariables() {
             try {
                 const response = await fetch('/api/templates/variables');
@@ -79,11 +95,11 @@ function templateEditor(configuredPageHeight = 1056) {
 }
 
 /**
- * Save template content - Global function for form submission
+ * Get editor content without the visual-only auto page breaks
  */
-function saveTemplateContent() {
+function getCleanTemplateContent() {
     const editor = document.getElementById('editor-content');
-    if (!editor) return;
+    if (!editor) return '';
 
     // Clone to clean
     const clone = editor.cloneNode(true);
@@ -92,7 +108,16 @@ function saveTemplateContent() {
     const autoBreaks = clone.querySelectorAll('.auto-break');
     autoBreaks.forEach(el => el.remove());
 
-    const content = clone.innerHTML;
-    document.getElementById('hidden-content-input').value = content;
+    return clone.innerHTML;
+}
+
+/**
+ * Save template content - Global function for form submission
+ */
+function saveTemplateContent() {
+    const editor = document.getElementById('editor-content');
+    if (!editor) return;
+
+    document.getElementById('hidden-content-input').value = getCleanTemplateContent();
     htmx.trigger('#save-template-form', 'submit');
 }
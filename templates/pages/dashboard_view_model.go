@@ -12,6 +12,7 @@ type DashboardStats struct {
 	PendingTasks         int64
 	RecentCases          []models.Case
 	UpcomingAppointments []models.Appointment
+	UpcomingRenewals     []models.ContractReminder
 	Notifications        []models.Notification
 	UnreadCount          int64
 }